@@ -0,0 +1,335 @@
+package test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseCacheFakeClockExpiry(t *testing.T) {
+	clock := y.NewFakeClock(time.Unix(0, 0))
+	c := y.NewBaseCache[string, int](y.CacheOption{TTL: time.Minute, Clock: clock})
+	c.Set("a", 1)
+	c.Set("b", 2, time.Hour)
+
+	clock.Advance(2 * time.Minute)
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+	v, ok := c.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+}
+
+func TestBaseCacheDelClearMemory(t *testing.T) {
+	c := y.NewBaseCache[string, string](y.CacheOption{})
+	c.Set("a", "1234")
+	c.Set("b", "12345678")
+	assert.Equal(t, uint64(5+9), c.MemoryUsage())
+
+	// Del 与 Clear 释放条目占用的内存
+	c.Del("a", "missing")
+	assert.Equal(t, uint64(9), c.MemoryUsage())
+	c.Clear()
+	assert.Equal(t, uint64(0), c.MemoryUsage())
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestBaseCacheDestroy(t *testing.T) {
+	c := y.NewBaseCache[string, int](y.CacheOption{})
+	c.Set("a", 1)
+	done := make(chan struct{})
+	go func() {
+		c.Destroy()
+		// 可以重复调用
+		c.Destroy()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Destroy deadlocked")
+	}
+	assert.Equal(t, 0, c.Len())
+	assert.Equal(t, uint64(0), c.MemoryUsage())
+}
+
+func TestBaseCacheDeleteExpired(t *testing.T) {
+	clock := y.NewFakeClock(time.Unix(0, 0))
+	c := y.NewBaseCache[int, int](y.CacheOption{Clock: clock})
+	for i := 0; i < 1000; i++ {
+		if i%2 == 0 {
+			c.Set(i, i, time.Second)
+		} else {
+			c.Set(i, i)
+		}
+	}
+	assert.Equal(t, 0, c.DeleteExpired())
+
+	clock.Advance(2 * time.Second)
+	assert.Equal(t, 500, c.DeleteExpired())
+	assert.Equal(t, 500, c.Len())
+	assert.Equal(t, uint64(500*16), c.MemoryUsage())
+}
+
+func TestBaseCacheDeleteExpiredConcurrentChange(t *testing.T) {
+	clock := y.NewFakeClock(time.Unix(0, 0))
+	c := y.NewBaseCache[int, int](y.CacheOption{Clock: clock})
+	// 第一批删除后，下一批从 128 开始
+	for i := 0; i < 300; i++ {
+		if i == 128 {
+			c.Set(i, i)
+		} else {
+			c.Set(i, i, time.Second)
+		}
+	}
+	changed := false
+	c.OnEvict(func(key int, value int, reason y.EvictReason) {
+		if changed {
+			return
+		}
+		changed = true
+		// 两批之间把下一批的起点移到最前面
+		c.Get(128)
+	})
+
+	clock.Advance(2 * time.Second)
+	assert.Equal(t, 299, c.DeleteExpired())
+	assert.Equal(t, 1, c.Len())
+	_, ok := c.Get(128)
+	assert.True(t, ok)
+}
+
+func TestBaseCacheJanitor(t *testing.T) {
+	clock := y.NewFakeClock(time.Unix(0, 0))
+	c := y.NewBaseCache[string, int](y.CacheOption{TTL: time.Second, CleanupInterval: time.Minute, Clock: clock})
+	c.Set("a", 1)
+	c.Set("b", 2, time.Hour)
+	assert.Equal(t, 2, c.Len())
+
+	// 没有人读取 a，依然会被后台清理
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	assert.Eventually(t, func() bool { return c.Len() == 1 }, time.Second, time.Millisecond)
+
+	c.Destroy()
+	c.Destroy()
+	assert.Equal(t, 0, c.Len())
+}
+
+type evictRecord struct {
+	key    string
+	value  int
+	reason y.EvictReason
+}
+
+func TestBaseCacheOnEvict(t *testing.T) {
+	clock := y.NewFakeClock(time.Unix(0, 0))
+	c := y.NewBaseCache[string, int](y.CacheOption{MaxSize: 2, Clock: clock})
+	var records []evictRecord
+	c.OnEvict(func(key string, value int, reason y.EvictReason) {
+		// 回调在锁外调用，可以访问缓存
+		c.Len()
+		records = append(records, evictRecord{key, value, reason})
+	})
+
+	c.Set("a", 1)
+	c.Set("a", 2)
+	c.Set("b", 3, time.Second)
+	c.Set("c", 4)
+	assert.Equal(t, []evictRecord{
+		{"a", 1, y.EvictReplaced},
+		{"a", 2, y.EvictCapacity},
+	}, records)
+
+	records = nil
+	clock.Advance(2 * time.Second)
+	_, ok := c.Get("b")
+	assert.False(t, ok)
+	c.Del("c", "missing")
+	assert.Equal(t, []evictRecord{
+		{"b", 3, y.EvictExpired},
+		{"c", 4, y.EvictDeleted},
+	}, records)
+
+	records = nil
+	c.Set("d", 5)
+	c.Set("e", 6)
+	assert.NoError(t, c.SetMemoryLimit("16"))
+	assert.Equal(t, []evictRecord{{"d", 5, y.EvictMemory}}, records)
+
+	records = nil
+	c.Destroy()
+	assert.Equal(t, []evictRecord{{"e", 6, y.EvictDeleted}}, records)
+	assert.Equal(t, "memory", y.EvictMemory.String())
+}
+
+func TestBaseCacheGetOrLoadSingleflight(t *testing.T) {
+	c := y.NewBaseCache[string, int](y.CacheOption{})
+	var calls int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results[0], _ = c.GetOrLoad("slow", func() (int, error) {
+			atomic.AddInt32(&calls, 1)
+			close(started)
+			<-release
+			return 42, nil
+		})
+	}()
+	<-started
+	for i := 1; i < len(results); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = c.GetOrLoad("slow", func() (int, error) {
+				atomic.AddInt32(&calls, 1)
+				return -1, nil
+			})
+		}(i)
+	}
+
+	// 加载期间其他键的读写与加载不受影响
+	c.Set("other", 1)
+	v, ok := c.Get("other")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	v, err := c.GetOrLoad("fast", func() (int, error) { return 2, nil })
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, r := range results {
+		assert.Equal(t, 42, r)
+	}
+	assert.Equal(t, uint64(2), c.Stats().Loads)
+}
+
+func TestBaseCacheGetOrLoadError(t *testing.T) {
+	clock := y.NewFakeClock(time.Unix(0, 0))
+	c := y.NewBaseCache[string, int](y.CacheOption{ErrorTTL: time.Second, Clock: clock})
+	errDB := errors.New("db down")
+	calls := 0
+	load := func() (int, error) {
+		calls++
+		if calls == 1 {
+			return 0, errDB
+		}
+		return calls, nil
+	}
+
+	_, err := c.GetOrLoad("a", load)
+	assert.ErrorIs(t, err, errDB)
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+
+	// 错误被缓存，到期前不再调用加载函数
+	_, err = c.GetOrLoad("a", load)
+	assert.ErrorIs(t, err, errDB)
+	assert.Equal(t, 1, calls)
+
+	clock.Advance(2 * time.Second)
+	v, err := c.GetOrLoad("a", load)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+
+	// Del 清除被缓存的错误
+	calls = 0
+	_, err = c.GetOrLoad("b", load)
+	assert.ErrorIs(t, err, errDB)
+	c.Del("b")
+	v, err = c.GetOrLoad("b", load)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+
+	_, err = c.GetOrLoad("c", func() (int, error) { panic("boom") })
+	assert.ErrorContains(t, err, "panic: boom")
+	s := c.Stats()
+	assert.Equal(t, uint64(5), s.Loads)
+	assert.Equal(t, uint64(3), s.LoadErrors)
+
+	// 没有设置 ErrorTTL 时每次都重新加载
+	c = y.NewBaseCache[string, int](y.CacheOption{})
+	calls = 0
+	c.GetOrLoad("a", load)
+	v, err = c.GetOrLoad("a", load)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+	// GetOrSetFunc 以原始值重新 panic
+	assert.PanicsWithValue(t, "boom", func() {
+		c.GetOrSetFunc("x", func() int { panic("boom") })
+	})
+}
+
+func TestBaseCacheGetOrLoadInvalidate(t *testing.T) {
+	c := y.NewBaseCache[string, int](y.CacheOption{})
+	tests := []struct {
+		invalidate func()
+		want       int
+		ok         bool
+	}{
+		{func() { c.Del("a") }, 0, false},
+		{func() { c.Clear() }, 0, false},
+		{func() { c.Set("a", 2) }, 2, true},
+	}
+	for _, tt := range tests {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			v, err := c.GetOrLoad("a", func() (int, error) {
+				close(started)
+				<-release
+				return 1, nil
+			})
+			// 调用方仍然拿到自己加载的值
+			assert.NoError(t, err)
+			assert.Equal(t, 1, v)
+		}()
+		<-started
+		tt.invalidate()
+		close(release)
+		<-done
+
+		// 加载结果不会覆盖加载期间的删除或写入
+		v, ok := c.Get("a")
+		assert.Equal(t, tt.ok, ok)
+		assert.Equal(t, tt.want, v)
+		c.Del("a")
+	}
+
+	// 删除后新的加载不再等待旧的加载
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.GetOrLoad("b", func() (int, error) {
+			close(started)
+			<-release
+			return 1, nil
+		})
+	}()
+	<-started
+	c.Del("b")
+	v, err := c.GetOrLoad("b", func() (int, error) { return 2, nil })
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+	close(release)
+	<-done
+	v, _ = c.Get("b")
+	assert.Equal(t, 2, v)
+}
@@ -0,0 +1,135 @@
+package test
+
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShardedCache(t *testing.T) {
+	c := y.NewShardedCache[string, int](5, y.CacheOption{})
+	assert.Equal(t, 8, c.Shards())
+
+	for i := 0; i < 100; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+	assert.Equal(t, 100, c.Len())
+	v, ok := c.Get("42")
+	assert.True(t, ok)
+	assert.Equal(t, 42, v)
+	assert.Equal(t, []int{1, 3}, c.Gets("1", "missing", "3"))
+
+	c.Del("1", "2")
+	assert.Equal(t, 98, c.Len())
+	_, ok = c.Get("1")
+	assert.False(t, ok)
+
+	calls := 0
+	load := func() int {
+		calls++
+		return 7
+	}
+	assert.Equal(t, 7, c.GetOrSetFunc("x", load))
+	assert.Equal(t, 7, c.GetOrSetFunc("x", load))
+	assert.Equal(t, 1, calls)
+	v, err := c.GetOrLoad("y", func() (int, error) { return 8, nil })
+	assert.NoError(t, err)
+	assert.Equal(t, 8, v)
+
+	c.SetMap(map[string]int{"a": 1, "b": 2})
+	assert.Equal(t, 102, c.Len())
+	assert.Greater(t, c.MemoryUsage(), uint64(0))
+
+	c.Clear()
+	assert.Equal(t, 0, c.Len())
+	assert.Equal(t, uint64(0), c.MemoryUsage())
+	c.Destroy()
+}
+
+func TestShardedCacheLimits(t *testing.T) {
+	c := y.NewShardedCache[int, int](4, y.CacheOption{MaxSize: 40, MaxMemory: "4k"})
+	assert.Equal(t, 40, c.Cap())
+	assert.Equal(t, uint64(4096), c.MemoryLimit())
+	for i := 0; i < 1000; i++ {
+		c.Set(i, i)
+	}
+	assert.LessOrEqual(t, c.Len(), 40)
+
+	// 平均到每个分片太小时拒绝
+	assert.Error(t, c.SetMemoryLimit("64"))
+	assert.NoError(t, c.SetMemoryLimit("5000"))
+	assert.Equal(t, uint64(5000), c.MemoryLimit())
+	assert.LessOrEqual(t, c.MemoryUsage(), uint64(5000))
+
+	// 余数分给前面的分片，总和与设置一致
+	c = y.NewShardedCache[int, int](8, y.CacheOption{MaxSize: 10})
+	assert.Equal(t, 8, c.Shards())
+	assert.Equal(t, 10, c.Cap())
+	for i := 0; i < 1000; i++ {
+		c.Set(i, i)
+	}
+	assert.LessOrEqual(t, c.Len(), 10)
+
+	// 限制太小时减少分片数，而不是让分片放不下条目
+	c = y.NewShardedCache[int, int](8, y.CacheOption{MaxSize: 3})
+	assert.Equal(t, 2, c.Shards())
+	assert.Equal(t, 3, c.Cap())
+	c = y.NewShardedCache[int, int](8, y.CacheOption{MaxMemory: "2k"})
+	assert.Equal(t, 2, c.Shards())
+	assert.Equal(t, uint64(2048), c.MemoryLimit())
+	c = y.NewShardedCache[int, int](8, y.CacheOption{MaxMemory: "100"})
+	assert.Equal(t, 1, c.Shards())
+	for i := 0; i < 8; i++ {
+		c.Set(i, i)
+	}
+	assert.Equal(t, 6, c.Len())
+}
+
+func TestShardedCacheHasher(t *testing.T) {
+	var calls int32
+	c := y.NewShardedCache[string, int](4, y.CacheOption{}, y.WithHasher(func(k string) uint64 {
+		atomic.AddInt32(&calls, 1)
+		return 0
+	}))
+	c.Set("a", 1)
+	c.Set("b", 2)
+	v, _ := c.Get("b")
+	assert.Equal(t, 2, v)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+const benchCacheKeys = 1024
+
+func benchmarkCacheGet(b *testing.B, get func(string) (int, bool)) {
+	keys := make([]string, benchCacheKeys)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			get(keys[i%benchCacheKeys])
+			i++
+		}
+	})
+}
+
+func BenchmarkBaseCacheGetParallel(b *testing.B) {
+	c := y.NewBaseCache[string, int](y.CacheOption{})
+	for i := 0; i < benchCacheKeys; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+	benchmarkCacheGet(b, c.Get)
+}
+
+func BenchmarkShardedCacheGetParallel(b *testing.B) {
+	c := y.NewShardedCache[string, int](0, y.CacheOption{})
+	for i := 0; i < benchCacheKeys; i++ {
+		c.Set(strconv.Itoa(i), i)
+	}
+	benchmarkCacheGet(b, c.Get)
+}
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseCacheStats(t *testing.T) {
+	clock := y.NewFakeClock(time.Unix(0, 0))
+	c := y.NewBaseCache[string, int](y.CacheOption{MaxSize: 2, Clock: clock})
+	c.Set("a", 1)
+	c.Set("b", 2, time.Second)
+	c.Get("a")
+	c.Get("a")
+	c.Get("missing")
+	clock.Advance(2 * time.Second)
+	c.Get("b")
+	c.Set("c", 3)
+	c.Set("d", 4)
+	c.GetOrSetFunc("e", func() int {
+		time.Sleep(time.Millisecond)
+		return 5
+	})
+
+	s := c.Stats()
+	assert.Equal(t, uint64(2), s.Hits)
+	assert.Equal(t, uint64(3), s.Misses)
+	assert.Equal(t, uint64(1), s.Expirations)
+	assert.Equal(t, uint64(2), s.Evictions)
+	assert.Equal(t, uint64(1), s.Loads)
+	assert.GreaterOrEqual(t, s.LoadTime, time.Millisecond)
+	assert.Equal(t, s.LoadTime, s.AvgLoadTime())
+	assert.Equal(t, 2, s.Entries)
+	assert.InDelta(t, 0.4, s.HitRate(), 1e-9)
+
+	old := c.ResetStats()
+	assert.Equal(t, s.Hits, old.Hits)
+	s = c.Stats()
+	assert.Equal(t, uint64(0), s.Hits)
+	assert.Equal(t, uint64(0), s.Misses)
+	assert.Equal(t, 2, s.Entries)
+}
+
+func TestResetStatsConcurrent(t *testing.T) {
+	c := y.NewBaseCache[int, int](y.CacheOption{})
+	c.Set(1, 1)
+	const workers, gets = 4, 2000
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < gets; j++ {
+				c.Get(1)
+			}
+		}()
+	}
+	// 重置期间并发的命中不会丢失
+	var total uint64
+	for i := 0; i < 100; i++ {
+		total += c.ResetStats().Hits
+	}
+	wg.Wait()
+	total += c.ResetStats().Hits
+	assert.Equal(t, uint64(workers*gets), total)
+}
+
+func TestPrometheusExporter(t *testing.T) {
+	a := y.NewBaseCache[string, int](y.CacheOption{})
+	a.Set("x", 1)
+	a.Get("x")
+	b := y.NewShardedCache[string, int](2, y.CacheOption{})
+	b.Get("y")
+
+	e := y.NewPrometheusExporter()
+	y.ExportCacheStats(e, "a", a)
+	y.ExportCacheStats(e, `b"2`, b)
+	var buf bytes.Buffer
+	n, err := e.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+
+	out := buf.String()
+	assert.Contains(t, out, "# HELP cache_hits_total Number of cache hits.\n# TYPE cache_hits_total counter\ncache_hits_total{cache=\"a\"} 1\ncache_hits_total{cache=\"b\\\"2\"} 0\n")
+	assert.Contains(t, out, "cache_misses_total{cache=\"b\\\"2\"} 1\n")
+	assert.Contains(t, out, "# TYPE cache_entries gauge\ncache_entries{cache=\"a\"} 1\n")
+	assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte("# TYPE cache_hits_total")))
+}
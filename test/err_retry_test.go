@@ -0,0 +1,132 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	exp := y.ExponentialBackoff(100*time.Millisecond, time.Second)
+	assert.Equal(t, 100*time.Millisecond, exp(1))
+	assert.Equal(t, 200*time.Millisecond, exp(2))
+	assert.Equal(t, 800*time.Millisecond, exp(4))
+	assert.Equal(t, time.Second, exp(5))
+	assert.Equal(t, time.Second, exp(100))
+
+	assert.Equal(t, 3*time.Second, y.FixedBackoff(3*time.Second)(7))
+
+	jitter := y.JitterBackoff(y.FixedBackoff(time.Second))
+	for i := 0; i < 100; i++ {
+		d := jitter(1)
+		assert.True(t, d >= 500*time.Millisecond && d < time.Second)
+	}
+}
+
+func TestRetrySucceeds(t *testing.T) {
+	clock := y.NewFakeClock(time.Unix(0, 0))
+	errTemp := errors.New("temporary")
+	var delays []time.Duration
+	var attempts []int
+	calls := 0
+
+	done := make(chan error, 1)
+	go func() {
+		done <- y.Retry(context.Background(), func(ctx context.Context) error {
+			calls++
+			if calls < 3 {
+				return errTemp
+			}
+			return nil
+		}, y.RetryPolicy{
+			MaxAttempts: 5,
+			Backoff:     y.ExponentialBackoff(time.Second, 0),
+			Clock:       clock,
+			OnAttempt:   func(attempt int, err error) { attempts = append(attempts, attempt) },
+			OnRetry:     func(attempt int, err error, delay time.Duration) { delays = append(delays, delay) },
+		})
+	}()
+	for i := 0; i < 2; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Hour)
+	}
+	assert.NoError(t, <-done)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []int{1, 2, 3}, attempts)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, delays)
+}
+
+func TestRetryDoGivesUp(t *testing.T) {
+	errTemp := errors.New("temporary")
+	calls := 0
+	_, err := y.RetryDo(context.Background(), func(ctx context.Context) (int, error) {
+		calls++
+		return 0, errTemp
+	}, y.RetryPolicy{MaxAttempts: 3, Backoff: y.FixedBackoff(0)})
+	var retryErr *y.RetryError
+	assert.True(t, errors.As(err, &retryErr))
+	assert.Equal(t, 3, retryErr.Attempts)
+	assert.True(t, errors.Is(err, errTemp))
+	assert.Equal(t, 3, calls)
+
+	// 不可重试的错误原样返回
+	errFatal := errors.New("fatal")
+	calls = 0
+	_, err = y.RetryDo(context.Background(), func(ctx context.Context) (int, error) {
+		calls++
+		return 0, errFatal
+	}, y.RetryPolicy{MaxAttempts: 3, Backoff: y.FixedBackoff(0), RetryIf: func(err error) bool { return err != errFatal }})
+	assert.Equal(t, errFatal, err)
+	assert.Equal(t, 1, calls)
+
+	// panic 同样参与重试
+	v, err := y.RetryDo(context.Background(), func(ctx context.Context) (int, error) {
+		calls++
+		if calls < 3 {
+			panic("boom")
+		}
+		return calls, nil
+	}, y.RetryPolicy{Backoff: y.FixedBackoff(0)})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v)
+}
+
+func TestRetryMaxElapsedAndCancel(t *testing.T) {
+	clock := y.NewFakeClock(time.Unix(0, 0))
+	calls := 0
+	done := make(chan error, 1)
+	go func() {
+		done <- y.Retry(context.Background(), func(ctx context.Context) error {
+			calls++
+			return errors.New("temporary")
+		}, y.RetryPolicy{
+			MaxElapsed: 10 * time.Second,
+			Backoff:    y.FixedBackoff(4 * time.Second),
+			Clock:      clock,
+		})
+	}()
+	for i := 0; i < 2; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(4 * time.Second)
+	}
+	err := <-done
+	var retryErr *y.RetryError
+	assert.True(t, errors.As(err, &retryErr))
+	// 0s、4s、8s 各一次，下一次等待会超过 10s
+	assert.Equal(t, 3, calls)
+
+	clock = y.NewFakeClock(time.Unix(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		clock.BlockUntil(1)
+		cancel()
+	}()
+	err = y.Retry(ctx, func(ctx context.Context) error {
+		return errors.New("temporary")
+	}, y.RetryPolicy{Backoff: y.FixedBackoff(time.Hour), MaxAttempts: 5, Clock: clock})
+	assert.True(t, errors.Is(err, context.Canceled))
+}
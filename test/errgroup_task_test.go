@@ -0,0 +1,85 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskGroupResultsInOrder(t *testing.T) {
+	var g y.TaskGroup[int]
+	g.SetLimit(3)
+	var last int32
+	var progressOK = true
+	g.OnProgress(func(done, total int) {
+		if int32(done) != atomic.AddInt32(&last, 1) || done > total {
+			progressOK = false
+		}
+	})
+	for i := 0; i < 10; i++ {
+		i := i
+		g.Go(func(ctx context.Context) (int, error) {
+			time.Sleep(time.Duration(10-i) * time.Millisecond)
+			return i * i, nil
+		})
+	}
+	results, err := g.Wait()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 4, 9, 16, 25, 36, 49, 64, 81}, results)
+	assert.True(t, progressOK)
+	assert.Equal(t, int32(10), last)
+}
+
+func TestTaskGroupErrors(t *testing.T) {
+	errBoom := errors.New("boom")
+	var g y.TaskGroup[string]
+	g.Go(func(ctx context.Context) (string, error) { return "a", nil })
+	g.Go(func(ctx context.Context) (string, error) { return "", errBoom })
+	g.Go(func(ctx context.Context) (string, error) { panic("oops") })
+	results, err := g.Wait()
+	assert.Equal(t, []string{"a", "", ""}, results)
+
+	var errs y.TaskErrors
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 2)
+	assert.Equal(t, 1, errs[0].Index)
+	assert.True(t, errors.Is(err, errBoom))
+	assert.Equal(t, 2, errs[1].Index)
+	assert.True(t, strings.HasPrefix(errs[1].Err.Error(), "panic: oops\n"))
+	assert.True(t, strings.Contains(errs[1].Err.Error(), "goroutine"))
+}
+
+func TestTaskGroupWithContext(t *testing.T) {
+	g, ctx := y.TaskGroupWithContext[int](context.Background())
+	g.SetLimit(1)
+	g.Go(func(ctx context.Context) (int, error) { return 0, errors.New("first") })
+	var ran int32
+	g.Go(func(ctx context.Context) (int, error) {
+		atomic.AddInt32(&ran, 1)
+		return 1, nil
+	})
+	_, err := g.Wait()
+	assert.Error(t, err)
+	assert.Equal(t, int32(0), ran)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Error(t, ctx.Err())
+}
+
+func TestTaskGroupTimeout(t *testing.T) {
+	var g y.TaskGroup[int]
+	g.SetTimeout(10 * time.Millisecond)
+	g.Go(func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	g.Go(func(ctx context.Context) (int, error) { return 2, nil })
+	results, err := g.Wait()
+	assert.Equal(t, []int{0, 2}, results)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
@@ -0,0 +1,86 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+func delayed[T any](d time.Duration, v T, err error) *y.Future[T] {
+	return y.Async(func() (T, error) {
+		time.Sleep(d)
+		return v, err
+	})
+}
+
+func TestAsyncAwait(t *testing.T) {
+	ctx := context.Background()
+	v, err := y.Async(func() (int, error) { return 1, nil }).Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	_, err = y.Async(func() (int, error) { panic("boom") }).Await(ctx)
+	assert.True(t, strings.HasPrefix(err.Error(), "panic: boom\nstack: "))
+
+	tctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = delayed(time.Second, 1, nil).Await(tctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	_, err = delayed(time.Second, 1, nil).Timeout(10 * time.Millisecond).Await(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestThenCatch(t *testing.T) {
+	ctx := context.Background()
+	s, err := y.Then(y.Async(func() (int, error) { return 21, nil }), func(v int) (string, error) {
+		return strconv.Itoa(v * 2), nil
+	}).Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "42", s)
+
+	errBoom := errors.New("boom")
+	called := false
+	failed := y.Then(delayed(0, 1, errBoom), func(v int) (int, error) {
+		called = true
+		return v, nil
+	})
+	_, err = failed.Await(ctx)
+	assert.Equal(t, errBoom, err)
+	assert.False(t, called)
+
+	v, err := failed.Catch(func(err error) (int, error) { return -1, nil }).Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, -1, v)
+}
+
+func TestAllAnyRace(t *testing.T) {
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	all, err := y.All(delayed(20*time.Millisecond, 1, nil), delayed(0, 2, nil), delayed(10*time.Millisecond, 3, nil)).Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, all)
+
+	_, err = y.All(delayed(time.Second, 1, nil), delayed(0, 2, errBoom)).Await(ctx)
+	assert.Equal(t, errBoom, err)
+
+	v, err := y.Any(delayed(0, 1, errBoom), delayed(10*time.Millisecond, 2, nil), delayed(time.Second, 3, nil)).Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v)
+
+	_, err = y.Any(delayed(0, 1, errBoom), delayed(0, 2, errBoom)).Await(ctx)
+	assert.True(t, errors.Is(err, errBoom))
+
+	_, err = y.Race(delayed(0, 1, errBoom), delayed(time.Second, 2, nil)).Await(ctx)
+	assert.Equal(t, errBoom, err)
+
+	_, err = y.Race[int]().Await(ctx)
+	assert.Error(t, err)
+}
@@ -0,0 +1,68 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDel(t *testing.T) {
+	arr := []int{1, 2, 3, 4, 5}
+	result, removed := y.Del(arr, func(v int) bool {
+		return v%2 == 0
+	})
+	assert.Equal(t, []int{1, 3, 5}, result)
+	assert.Equal(t, 2, removed)
+	// 原切片不变
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, arr)
+
+	result, removed = y.Del(arr, func(v *int, i int) bool {
+		return i < 2
+	})
+	assert.Equal(t, []int{3, 4, 5}, result)
+	assert.Equal(t, 2, removed)
+}
+
+func TestDelOptions(t *testing.T) {
+	result, removed := y.Del([]int{1, 2, 3, 4}, y.Is, 1, 3)
+	assert.Equal(t, []int{2, 4}, result)
+	assert.Equal(t, 2, removed)
+
+	result, removed = y.Del([]int{1, 2, 3, 4}, y.Not, 1, 3)
+	assert.Equal(t, []int{1, 3}, result)
+	assert.Equal(t, 2, removed)
+
+	result, removed = y.Del([]int{0, 1, 0, 2}, y.NotEmpty)
+	assert.Equal(t, []int{1, 2}, result)
+	assert.Equal(t, 2, removed)
+
+	result, removed = y.Del([]int{0, 1, 2, 3}, y.NotEmpty, y.Is, 3)
+	assert.Equal(t, []int{1, 2}, result)
+	assert.Equal(t, 2, removed)
+
+	result, removed = y.Del([]int{1, 2}, y.Is, 5)
+	assert.Equal(t, []int{1, 2}, result)
+	assert.Equal(t, 0, removed)
+
+	ptrs, removed := y.Del([]*int{intPtr(1), nil, intPtr(2)}, y.NotNil)
+	assert.Len(t, ptrs, 2)
+	assert.Equal(t, 1, removed)
+}
+
+func TestDelInPlace(t *testing.T) {
+	arr := []int{1, 2, 3, 4, 5}
+	backing := arr
+	removed := y.DelInPlace(&arr, func(v int) bool {
+		return v > 3
+	})
+	assert.Equal(t, 2, removed)
+	assert.Equal(t, []int{1, 2, 3}, arr)
+	// 复用底层数组，尾部被清空
+	assert.Equal(t, []int{1, 2, 3, 0, 0}, backing)
+
+	strs := []string{"a", "", "b", ""}
+	removed = y.DelInPlace(&strs, y.NotEmpty)
+	assert.Equal(t, 2, removed)
+	assert.Equal(t, []string{"a", "b"}, strs)
+}
@@ -0,0 +1,85 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+type tagged struct {
+	Name string
+	Tags []string
+	Meta map[string]any
+}
+
+func TestDistinctByUnhashable(t *testing.T) {
+	items := []tagged{
+		{"a", []string{"x", "y"}, map[string]any{"k": []int{1}}},
+		{"b", []string{"x", "y"}, map[string]any{"k": []int{1}}},
+		{"c", []string{"y", "x"}, nil},
+		{"d", []string{"x", "y"}, map[string]any{"k": []int{2}}},
+	}
+	// 整个结构体作为键会因为切片字段无法放入 map，这里使用结构化哈希
+	result := y.DistinctBy(items, func(v tagged) tagged {
+		v.Name = ""
+		return v
+	})
+	assert.Equal(t, []string{"a", "c", "d"}, y.Flex(result, func(v tagged, i int) string { return v.Name }))
+
+	result = y.DistinctBy(items, func(v tagged) []string { return v.Tags })
+	assert.Equal(t, []string{"a", "c"}, y.Flex(result, func(v tagged, i int) string { return v.Name }))
+
+	// any 类型的键混合可比较与不可比较的值
+	mixed := y.DistinctBy([]any{1, []int{1}, 1, []int{1}, "1"}, func(v any) any { return v })
+	assert.Equal(t, []any{1, []int{1}, "1"}, mixed)
+}
+
+func TestDistinctByPolicies(t *testing.T) {
+	type row struct {
+		ID    int
+		Value int
+	}
+	rows := []row{{1, 10}, {2, 20}, {1, 11}, {3, 30}, {2, 21}}
+	id := func(r row) int { return r.ID }
+
+	assert.Equal(t, []row{{1, 10}, {2, 20}, {3, 30}}, y.DistinctBy(rows, id))
+	assert.Equal(t, []row{{1, 10}, {2, 20}, {3, 30}}, y.DistinctBy(rows, id, y.KeepFirst))
+	assert.Equal(t, []row{{1, 11}, {3, 30}, {2, 21}}, y.DistinctBy(rows, id, y.KeepLast))
+
+	merged := y.DistinctBy(rows, id, y.WithMerge(func(kept, dup row) row {
+		kept.Value += dup.Value
+		return kept
+	}))
+	assert.Equal(t, []row{{1, 21}, {2, 41}, {3, 30}}, merged)
+}
+
+func TestDistinctByCustomHasher(t *testing.T) {
+	words := []string{"Go", "go", "Rust", "GO", "rust", "zig"}
+	result := y.DistinctBy(words, func(s string) string { return s },
+		y.WithHasher(func(s string) uint64 { return y.Hash(strings.ToLower(s)) }),
+		y.WithEqual(strings.EqualFold),
+	)
+	assert.Equal(t, []string{"Go", "Rust", "zig"}, result)
+
+	// 只指定 WithEqual 时同样生效
+	id := func(s string) string { return s }
+	assert.Equal(t, []string{"a", "b"}, y.DistinctBy([]string{"a", "A", "b"}, id, y.WithEqual(strings.EqualFold)))
+	assert.Equal(t, []string{"A", "b"}, y.DistinctBy([]string{"a", "A", "b"}, id, y.WithEqual(strings.EqualFold), y.KeepLast))
+}
+
+func TestHash(t *testing.T) {
+	a := map[string]any{"x": []int{1, 2}, "y": map[int]string{1: "a"}}
+	b := map[string]any{"y": map[int]string{1: "a"}, "x": []int{1, 2}}
+	assert.Equal(t, y.Hash(a), y.Hash(b))
+	assert.NotEqual(t, y.Hash([]int{1, 2}), y.Hash([]int{2, 1}))
+
+	type node struct {
+		Next *node
+		V    int
+	}
+	n := &node{V: 1}
+	n.Next = n
+	assert.NotPanics(t, func() { y.Hash(n) })
+}
@@ -0,0 +1,121 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEach(t *testing.T) {
+	var visited []int
+	y.ForEach([]int{1, 2, 3, 4}, func(v int) bool {
+		visited = append(visited, v)
+		return v < 2
+	})
+	assert.Equal(t, []int{1, 2}, visited)
+
+	visited = nil
+	y.ForEach([]int{1, 2, 3, 4}, func(v int, i int) {
+		visited = append(visited, i)
+	}, y.UseReverse)
+	assert.Equal(t, []int{3, 2, 1, 0}, visited)
+
+	arr := []int{1, 0, 2}
+	y.ForEach(arr, func(v *int) {
+		*v *= 10
+	}, y.NotEmpty)
+	assert.Equal(t, []int{10, 0, 20}, arr)
+}
+
+func TestParallelEach(t *testing.T) {
+	arr := make([]int, 200)
+	for i := range arr {
+		arr[i] = i
+	}
+	r := rand.New(rand.NewSource(1))
+	delays := make([]time.Duration, len(arr))
+	for i := range delays {
+		delays[i] = time.Duration(r.Intn(300)) * time.Microsecond
+	}
+	var next int
+	for result := range y.ParallelEach(context.Background(), arr, func(ctx context.Context, v int, i int) (int, error) {
+		time.Sleep(delays[i])
+		if v == 50 {
+			panic("test panic")
+		}
+		if v == 60 {
+			return 0, errors.New("bad item")
+		}
+		return v * 2, nil
+	}, y.WithLimit(8)) {
+		assert.Equal(t, next, result.Index)
+		switch result.Index {
+		case 50:
+			assert.Error(t, result.Err)
+		case 60:
+			assert.EqualError(t, result.Err, "bad item")
+		default:
+			assert.NoError(t, result.Err)
+			assert.Equal(t, result.Index*2, result.Value)
+		}
+		next++
+	}
+	assert.Equal(t, len(arr), next)
+}
+
+func TestParallelEachBounded(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var started int32
+	ch := y.ParallelEach(ctx, make([]int, 1000), func(ctx context.Context, v int, i int) (int, error) {
+		atomic.AddInt32(&started, 1)
+		return v, nil
+	}, y.WithLimit(2))
+	<-ch
+	time.Sleep(20 * time.Millisecond)
+	// 消费者停止读取后，最多领先 2*limit 个结果
+	assert.LessOrEqual(t, atomic.LoadInt32(&started), int32(6))
+
+	cancel()
+	var last y.EachResult[int]
+	for last = range ch {
+	}
+	assert.ErrorIs(t, last.Err, context.Canceled)
+	assert.Greater(t, last.Index, 0)
+	assert.Less(t, last.Index, 1000)
+}
+
+func TestParallelEachTimeout(t *testing.T) {
+	var results []y.EachResult[int]
+	for r := range y.ParallelEach(context.Background(), make([]int, 100), func(ctx context.Context, v int, i int) (int, error) {
+		if i == 3 {
+			<-ctx.Done()
+		}
+		return i, nil
+	}, y.WithLimit(2), y.WithTimeout(20*time.Millisecond)) {
+		results = append(results, r)
+	}
+	last := results[len(results)-1]
+	assert.ErrorIs(t, last.Err, context.DeadlineExceeded)
+	assert.Less(t, len(results), 100)
+	for i, r := range results[:len(results)-1] {
+		assert.Equal(t, i, r.Index)
+	}
+	assert.Equal(t, len(results)-1, last.Index)
+
+	// 正常结束时不会有额外的结果
+	n := 0
+	for r := range y.ParallelEach(context.Background(), []int{1, 2, 3}, func(ctx context.Context, v int, i int) (int, error) {
+		return v, nil
+	}) {
+		assert.NoError(t, r.Err)
+		n++
+	}
+	assert.Equal(t, 3, n)
+}
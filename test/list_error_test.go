@@ -0,0 +1,94 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlexE(t *testing.T) {
+	result, err := y.FlexE([]string{"1", "2", "3"}, func(s string, i int) (int, error) {
+		return strconv.Atoi(s)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, result)
+
+	// 第一个错误
+	_, err = y.FlexE([]string{"1", "a", "b"}, func(s string, i int) (int, error) {
+		return strconv.Atoi(s)
+	})
+	var indexErr *y.IndexError
+	assert.True(t, errors.As(err, &indexErr))
+	assert.Equal(t, 1, indexErr.Index)
+
+	// 异步收集所有错误
+	_, err = y.FlexE([]string{"1", "a", "3", "b"}, func(s string, i int) (int, error) {
+		return strconv.Atoi(s)
+	}, y.UseAsync, y.UseAllErrors)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "index 1")
+	assert.Contains(t, err.Error(), "index 3")
+
+	// panic 也会带上下标
+	_, err = y.FlexE([]int{1, 2, 3}, func(v int, i int) (int, error) {
+		if v == 3 {
+			panic("test panic")
+		}
+		return v, nil
+	}, y.UseAsync)
+	assert.True(t, errors.As(err, &indexErr))
+	assert.Equal(t, 2, indexErr.Index)
+}
+
+func TestFilterE(t *testing.T) {
+	errOdd := errors.New("odd")
+	result, err := y.FilterE([]int{1, 2, 3, 4}, func(v int) (bool, error) {
+		return v%2 == 0, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 4}, result)
+
+	_, err = y.FilterE([]int{2, 4, 5, 7}, func(v *int, i int) (bool, error) {
+		if *v%2 == 1 {
+			return false, errOdd
+		}
+		return true, nil
+	}, y.UseAsync, y.UseAllErrors)
+	assert.True(t, errors.Is(err, errOdd))
+	assert.Contains(t, err.Error(), "index 2")
+	assert.Contains(t, err.Error(), "index 3")
+}
+
+func TestReduceE(t *testing.T) {
+	sum, err := y.ReduceE([]string{"1", "2", "3"}, func(acc int, s string) (int, error) {
+		v, err := strconv.Atoi(s)
+		return acc + v, err
+	}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, sum)
+
+	_, err = y.ReduceE([]string{"1", "x", "3"}, func(acc int, s string) (int, error) {
+		v, err := strconv.Atoi(s)
+		return acc + v, err
+	}, 0)
+	var indexErr *y.IndexError
+	assert.True(t, errors.As(err, &indexErr))
+	assert.Equal(t, 1, indexErr.Index)
+}
+
+func TestDistinctE(t *testing.T) {
+	result, err := y.DistinctE([]int{1, 2, 3, 4}, func(v int) (any, error) {
+		return v % 2, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, result)
+
+	_, err = y.DistinctE([]int{1, 2}, func(v int, i int) (any, error) {
+		return nil, fmt.Errorf("bad %d", v)
+	})
+	assert.EqualError(t, err, "index 0: bad 1")
+}
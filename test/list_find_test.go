@@ -0,0 +1,54 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFind(t *testing.T) {
+	arr := []int{1, 2, 3, 4, 3}
+	v, ok := y.Find(arr, func(v int) bool { return v > 2 })
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+
+	_, ok = y.Find(arr, func(v int) bool { return v > 10 })
+	assert.False(t, ok)
+
+	v, ok = y.Find(arr, func(v *int, i int) bool { return i == 1 })
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+
+	v, ok = y.FindLast(arr, func(v int) bool { return v < 4 })
+	assert.True(t, ok)
+	assert.Equal(t, 3, v)
+}
+
+func TestFindOptions(t *testing.T) {
+	arr := []int{3, 1, 3, 0, 3}
+	assert.Equal(t, 0, y.Pos(arr, 3))
+	assert.Equal(t, 2, y.Pos(arr, 3, y.WithOffset(1)))
+	assert.Equal(t, 4, y.Pos(arr, 3, y.WithOffset(-1)))
+	assert.Equal(t, 4, y.PosLast(arr, 3))
+	assert.Equal(t, 2, y.PosLast(arr, 3, y.WithOffset(3)))
+	assert.Equal(t, -1, y.Pos(arr, 3, y.WithOffset(10)))
+	assert.Equal(t, 3, y.Pos(arr, func(v int) bool { return v < 3 }, y.UseReverse))
+	assert.Equal(t, 1, y.Pos(arr, func(v int) bool { return v < 3 }, y.NotEmpty))
+
+	ptrs := []*int{nil, intPtr(1)}
+	assert.Equal(t, 1, y.Pos(ptrs, func(v *int) bool { return true }, y.NotNil))
+
+	assert.True(t, y.Has(arr, 0))
+	assert.False(t, y.Has(arr, 0, y.NotEmpty))
+}
+
+func TestPosAllAndCount(t *testing.T) {
+	arr := []string{"a", "b", "a", "c", "a"}
+	assert.Equal(t, []int{0, 2, 4}, y.PosAll(arr, "a"))
+	assert.Equal(t, []int{4, 2, 0}, y.PosAll(arr, "a", y.UseReverse))
+	assert.Equal(t, []int{2, 4}, y.PosAll(arr, "a", y.WithOffset(1)))
+	assert.Equal(t, []int{}, y.PosAll(arr, "z"))
+	assert.Equal(t, 3, y.Count(arr, "a"))
+	assert.Equal(t, 2, y.Count(arr, func(v string, i int) bool { return v != "a" }))
+}
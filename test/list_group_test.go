@@ -0,0 +1,88 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+type employee struct {
+	Name string
+	Dept string
+}
+
+var employees = []employee{
+	{"Alice", "dev"},
+	{"Bob", "ops"},
+	{"Carol", "dev"},
+	{"Dave", "qa"},
+	{"Eve", "ops"},
+}
+
+func TestGroup(t *testing.T) {
+	groups := y.Group[string](employees, func(e employee) string {
+		return e.Dept
+	})
+	assert.Equal(t, [][]employee{
+		{{"Alice", "dev"}, {"Carol", "dev"}},
+		{{"Bob", "ops"}, {"Eve", "ops"}},
+		{{"Dave", "qa"}},
+	}, groups)
+
+	// nil 键会被忽略
+	anyGroups := y.Group[any]([]int{1, 2, 3, 4}, func(v int, i int) any {
+		if v == 3 {
+			return nil
+		}
+		return v % 2
+	})
+	assert.Equal(t, [][]int{{1}, {2, 4}}, anyGroups)
+}
+
+func TestGroupMap(t *testing.T) {
+	mp := y.GroupMap[string](employees, func(e *employee) string {
+		return e.Dept
+	})
+	assert.Equal(t, []string{"dev", "ops", "qa"}, mp.Keys())
+	dev, ok := mp.Get("dev")
+	assert.True(t, ok)
+	assert.Equal(t, []employee{{"Alice", "dev"}, {"Carol", "dev"}}, dev)
+}
+
+func TestToMap(t *testing.T) {
+	mp := y.ToMap[string](employees, func(e *employee, i int) string {
+		return e.Dept
+	})
+	assert.Equal(t, []string{"dev", "ops", "qa"}, mp.Keys())
+	ops, _ := mp.Get("ops")
+	assert.Equal(t, "Eve", ops.Name)
+}
+
+func TestCountBy(t *testing.T) {
+	mp := y.CountBy[string](employees, func(e employee) string {
+		return e.Dept
+	})
+	assert.Equal(t, []string{"dev", "ops", "qa"}, mp.Keys())
+	assert.Equal(t, []int{2, 2, 1}, mp.Vals())
+}
+
+func TestPartition(t *testing.T) {
+	even, odd := y.Partition([]int{1, 2, 3, 4, 5}, func(v int) bool {
+		return v%2 == 0
+	})
+	assert.Equal(t, []int{2, 4}, even)
+	assert.Equal(t, []int{1, 3, 5}, odd)
+
+	matched, rest := y.Partition([]int{1, 2, 3}, y.Is, 2)
+	assert.Equal(t, []int{2}, matched)
+	assert.Equal(t, []int{1, 3}, rest)
+}
+
+func TestChunk(t *testing.T) {
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, y.Chunk([]int{1, 2, 3, 4, 5}, 2))
+	assert.Equal(t, [][]int{}, y.Chunk([]int{}, 3))
+	assert.Panics(t, func() {
+		y.Chunk([]int{1}, 0)
+	})
+}
@@ -0,0 +1,81 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+type joinUser struct {
+	ID   int
+	Name string
+}
+
+type joinOrder struct {
+	UserID int
+	Amount int
+}
+
+type joinRow struct {
+	ID     int
+	Name   string
+	UserID int
+	Amount int
+}
+
+var (
+	joinUsers  = []joinUser{{1, "Alice"}, {2, "Bob"}, {3, "Carol"}}
+	joinOrders = []joinOrder{{1, 10}, {3, 30}, {1, 11}, {4, 40}}
+	userKey    = func(u joinUser) int { return u.ID }
+	orderKey   = func(o joinOrder) int { return o.UserID }
+)
+
+func TestInnerJoin(t *testing.T) {
+	pairs := y.InnerJoin(joinUsers, joinOrders, userKey, orderKey)
+	assert.Equal(t, []y.Tuple2[joinUser, joinOrder]{
+		y.T(joinUser{1, "Alice"}, joinOrder{1, 10}),
+		y.T(joinUser{1, "Alice"}, joinOrder{1, 11}),
+		y.T(joinUser{3, "Carol"}, joinOrder{3, 30}),
+	}, pairs)
+
+	// 哈希较小的一侧，结果顺序不变
+	swapped := y.InnerJoin(joinOrders, joinUsers[:2], orderKey, userKey)
+	assert.Equal(t, []y.Tuple2[joinOrder, joinUser]{
+		y.T(joinOrder{1, 10}, joinUser{1, "Alice"}),
+		y.T(joinOrder{1, 11}, joinUser{1, "Alice"}),
+	}, swapped)
+}
+
+func TestLeftAndRightJoin(t *testing.T) {
+	left := y.LeftJoin(joinUsers, joinOrders, userKey, orderKey)
+	assert.Len(t, left, 4)
+	assert.Equal(t, "Bob", left[2].Alpha().Name)
+	assert.Nil(t, left[2].Beta())
+	assert.Equal(t, 11, left[1].Beta().Amount)
+
+	right := y.RightJoin(joinUsers, joinOrders, userKey, orderKey)
+	assert.Len(t, right, 4)
+	assert.Equal(t, "Alice", right[0].Alpha().Name)
+	assert.Nil(t, right[3].Alpha())
+	assert.Equal(t, 40, right[3].Beta().Amount)
+}
+
+func TestOuterJoin(t *testing.T) {
+	pairs := y.OuterJoin(joinUsers, joinOrders, userKey, orderKey)
+	assert.Len(t, pairs, 5)
+	assert.Nil(t, pairs[2].Beta())
+	assert.Equal(t, "Bob", pairs[2].Alpha().Name)
+	assert.Nil(t, pairs[4].Alpha())
+	assert.Equal(t, 4, pairs[4].Beta().UserID)
+
+	rows, err := y.JoinAs[joinRow](pairs)
+	assert.NoError(t, err)
+	assert.Equal(t, []joinRow{
+		{1, "Alice", 1, 10},
+		{1, "Alice", 1, 11},
+		{2, "Bob", 0, 0},
+		{3, "Carol", 3, 30},
+		{0, "", 4, 40},
+	}, rows)
+}
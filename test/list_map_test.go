@@ -1,6 +1,8 @@
 package test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -227,6 +229,95 @@ func TestMapTypeConversion(t *testing.T) {
 	}
 }
 
+func TestFlexCtx(t *testing.T) {
+	arr := []int{1, 2, 3, 4, 5}
+	result, err := y.FlexCtx(context.Background(), arr, func(ctx context.Context, item int, index int) (int, error) {
+		return item * 2, nil
+	}, y.UseAsync, y.WithLimit(2))
+	if err != nil {
+		t.Fatalf("TestFlexCtx failed, unexpected error %v", err)
+	}
+	expected := []int{2, 4, 6, 8, 10}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("TestFlexCtx failed, expected %v, got %v", expected, result)
+	}
+
+	// 并发数限制
+	var running, maxRunning int32
+	_, err = y.FlexCtx(context.Background(), make([]int, 20), func(ctx context.Context, item int, index int) (int, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(2 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return item, nil
+	}, y.UseAsync, y.WithLimit(3))
+	if err != nil {
+		t.Fatalf("TestFlexCtx limit failed, unexpected error %v", err)
+	}
+	if maxRunning > 3 {
+		t.Errorf("TestFlexCtx limit failed, expected at most 3 running, got %d", maxRunning)
+	}
+}
+
+func TestFlexCtxError(t *testing.T) {
+	errBoom := errors.New("boom")
+	var processed int32
+	_, err := y.FlexCtx(context.Background(), make([]int, 100), func(ctx context.Context, item int, index int) (int, error) {
+		atomic.AddInt32(&processed, 1)
+		if index == 0 {
+			return 0, errBoom
+		}
+		time.Sleep(time.Millisecond)
+		return item, nil
+	}, y.UseAsync, y.WithLimit(1))
+	if !errors.Is(err, errBoom) {
+		t.Errorf("TestFlexCtxError failed, expected %v, got %v", errBoom, err)
+	}
+	if processed >= 100 {
+		t.Errorf("TestFlexCtxError failed, expected remaining work to be skipped")
+	}
+
+	// panic 转换为 error
+	_, err = y.FlexCtx(context.Background(), []int{1, 2, 3}, func(ctx context.Context, item int, index int) (int, error) {
+		if item == 2 {
+			panic("test panic")
+		}
+		return item, nil
+	})
+	if err == nil {
+		t.Errorf("TestFlexCtxError failed, expected panic to be returned as error")
+	}
+}
+
+func TestFlexCtxCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := y.FlexCtx(ctx, []int{1, 2, 3}, func(ctx context.Context, item int, index int) (int, error) {
+		return item, nil
+	}, y.UseAsync)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("TestFlexCtxCancel failed, expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestFlatFlexCtx(t *testing.T) {
+	result, err := y.FlatFlexCtx(context.Background(), []int{1, 2, 3}, func(ctx context.Context, item int, index int) ([]int, error) {
+		return []int{item, item}, nil
+	}, y.UseAsync, y.UseDistinct)
+	if err != nil {
+		t.Fatalf("TestFlatFlexCtx failed, unexpected error %v", err)
+	}
+	expected := []int{1, 2, 3}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("TestFlatFlexCtx failed, expected %v, got %v", expected, result)
+	}
+}
+
 // Helper for TestMapIgnoreNil
 func intPtr(i int) *int {
 	return &i
@@ -0,0 +1,61 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScan(t *testing.T) {
+	assert.Equal(t, []int{1, 3, 6, 10}, y.Scan([]int{1, 2, 3, 4}, func(acc int, v int) int {
+		return acc + v
+	}, 0))
+	assert.Equal(t, []int{}, y.Scan([]int{}, func(acc int, v int) int { return acc + v }, 0))
+}
+
+func TestWindow(t *testing.T) {
+	arr := []int{1, 2, 3, 4, 5}
+	assert.Equal(t, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}, y.Window(arr, 3, 1))
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}}, y.Window(arr, 2, 2))
+	assert.Equal(t, [][]int{}, y.Window(arr, 6, 1))
+	assert.Panics(t, func() { y.Window(arr, 0, 1) })
+
+	// 移动平均
+	avgs := y.Flex(y.Window([]float64{1, 2, 3, 4}, 2, 1), func(w []float64, i int) float64 {
+		return y.Avg(w)
+	})
+	assert.Equal(t, []float64{1.5, 2.5, 3.5}, avgs)
+}
+
+func TestPairwise(t *testing.T) {
+	deltas := y.Flex2(y.Pairwise([]int{1, 4, 9, 16}), func(a, b int, i int) int {
+		return b - a
+	})
+	assert.Equal(t, []int{3, 5, 7}, deltas)
+	assert.Len(t, y.Pairwise([]int{1}), 0)
+}
+
+func TestAggregates(t *testing.T) {
+	assert.Equal(t, 10, y.Sum([]int{1, 2, 3, 4}))
+	assert.Equal(t, 2.5, y.Avg([]int{1, 2, 3, 4}))
+	assert.Equal(t, 0.0, y.Avg([]int{}))
+
+	min, ok := y.Min([]int{3, 1, 2})
+	assert.True(t, ok)
+	assert.Equal(t, 1, min)
+	max, _ := y.Max([]string{"b", "c", "a"})
+	assert.Equal(t, "c", max)
+	_, ok = y.Max([]int{})
+	assert.False(t, ok)
+
+	type point struct {
+		Name string
+		V    float64
+	}
+	points := []point{{"a", 2}, {"b", 1}, {"c", 3}, {"d", 1}}
+	p, _ := y.MinBy(points, func(p point) float64 { return p.V })
+	assert.Equal(t, "b", p.Name)
+	p, _ = y.MaxBy(points, func(p point) float64 { return p.V })
+	assert.Equal(t, "c", p.Name)
+}
@@ -0,0 +1,65 @@
+package test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeqPipeline(t *testing.T) {
+	arr := []int{5, 3, 8, 3, 1, 8, 10, 2}
+	result := y.SeqFlex(
+		y.NewSeq(arr).Filter(func(v int) bool { return v > 2 }),
+		func(v int, i int) int { return v * 10 },
+	).Distinct().Sort(func(a, b int) bool { return a < b })
+	assert.Equal(t, []int{30, 50, 80, 100}, result)
+	// 原切片不变
+	assert.Equal(t, []int{5, 3, 8, 3, 1, 8, 10, 2}, arr)
+}
+
+func TestSeqLazy(t *testing.T) {
+	calls := 0
+	result := y.SeqFlex(y.NewSeq([]int{1, 2, 3, 4, 5, 6}), func(v int, i int) int {
+		calls++
+		return v * 2
+	}).Skip(1).Take(2).Collect()
+	assert.Equal(t, []int{4, 6}, result)
+	assert.Equal(t, 3, calls)
+
+	// 未执行终止操作时不会遍历
+	calls = 0
+	seq := y.SeqFlex(y.NewSeq([]int{1, 2, 3}), func(v int, i int) int {
+		calls++
+		return v
+	})
+	assert.Equal(t, 0, calls)
+	assert.Equal(t, 3, seq.Count())
+	assert.Equal(t, 3, calls)
+}
+
+func TestSeqFilterForms(t *testing.T) {
+	arr := []int{1, 2, 3, 4}
+	assert.Equal(t, []int{3, 4}, y.NewSeq(arr).Filter(func(v *int) bool { return *v > 2 }).Collect())
+	assert.Equal(t, []int{2, 4}, y.NewSeq(arr).Filter(func(v *int, i int) bool { return i%2 == 1 }).Collect())
+	assert.Equal(t, []int{1, 3}, y.NewSeq(arr).Filter(y.Is, 1, 3).Collect())
+	assert.Panics(t, func() {
+		y.NewSeq(arr).Filter(func(v string) bool { return true })
+	})
+}
+
+func TestSeqOptions(t *testing.T) {
+	result := y.NewSeq([]int{0, 1, 2, 2, 0, 3}).Filter(y.Not, 3, y.NotEmpty, y.UseDistinct).Collect()
+	assert.Equal(t, []int{1, 2}, result)
+
+	strs := y.SeqFlatFlex(y.NewSeq([]int{1, 2}), func(v int, i int) []string {
+		return []string{"", strconv.Itoa(v), strconv.Itoa(v)}
+	}, y.NotEmpty, y.UseDistinct).Collect()
+	assert.Equal(t, []string{"1", "2"}, strs)
+
+	sum := y.SeqReduce(y.NewSeq([]int{1, 2, 3}).Filter(func(v int, i int) bool { return i > 0 }), func(acc int, v int) int {
+		return acc + v
+	}, 0)
+	assert.Equal(t, 5, sum)
+}
@@ -0,0 +1,56 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAlgebra(t *testing.T) {
+	a := []int{1, 2, 2, 3, 4}
+	b := []int{3, 4, 4, 5, 6}
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, y.Union(a, b))
+	assert.Equal(t, []int{3, 4}, y.Intersect(a, b))
+	assert.Equal(t, []int{1, 2}, y.Except(a, b))
+	assert.Equal(t, []int{1, 2, 5, 6}, y.SymmetricDiff(a, b))
+
+	assert.Equal(t, []int{}, y.Intersect([]int{}, b))
+	assert.Equal(t, []int{1, 2, 3}, y.Except([]int{1, 2, 3}, nil))
+}
+
+func TestSetAlgebraKey(t *testing.T) {
+	type account struct {
+		ID     int
+		Source string
+	}
+	db := []account{{1, "db"}, {2, "db"}, {3, "db"}}
+	api := []account{{2, "api"}, {3, "api"}, {4, "api"}}
+	key := func(v account) any { return v.ID }
+
+	assert.Equal(t, []account{{1, "db"}, {2, "db"}, {3, "db"}, {4, "api"}}, y.Union(db, api, key))
+	assert.Equal(t, []account{{2, "db"}, {3, "db"}}, y.Intersect(db, api, key))
+	assert.Equal(t, []account{{1, "db"}}, y.Except(db, api, func(v *account, i int) any { return v.ID }))
+	assert.Equal(t, []account{{1, "db"}, {4, "api"}}, y.SymmetricDiff(db, api, key, y.UseAsync))
+}
+
+func TestSetAlgebraAsync(t *testing.T) {
+	a := make([]int, 10000)
+	b := make([]int, 10000)
+	for i := range a {
+		a[i] = i
+		b[i] = i + 5000
+	}
+	assert.Equal(t, y.Intersect(a, b), y.Intersect(a, b, y.UseAsync))
+	assert.Len(t, y.Union(a, b, y.UseAsync), 15000)
+	assert.Len(t, y.SymmetricDiff(a, b, y.UseAsync), 10000)
+
+	assert.Panics(t, func() {
+		y.Union(a, b, func(v int) any {
+			if v == 9999 {
+				panic("bad key")
+			}
+			return v
+		}, y.UseAsync)
+	})
+}
@@ -0,0 +1,227 @@
+package test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+type sortItem struct {
+	Key   int
+	Name  string
+	Score *int
+	Index int
+}
+
+func makeSortItems(n int, pattern string, r *rand.Rand) []sortItem {
+	items := make([]sortItem, n)
+	for i := range items {
+		var key int
+		switch pattern {
+		case "random":
+			key = r.Intn(n + 1)
+		case "fewKeys":
+			key = r.Intn(4)
+		case "sorted":
+			key = i
+		case "reversed":
+			key = n - i
+		case "sawtooth":
+			key = i % 97
+		case "nearlySorted":
+			key = i
+			if r.Intn(20) == 0 {
+				key = r.Intn(n + 1)
+			}
+		}
+		items[i] = sortItem{Key: key, Index: i}
+	}
+	return items
+}
+
+// TestSortStable 与 sort.SliceStable 对比，验证排序结果与稳定性
+func TestSortStable(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	patterns := []string{"random", "fewKeys", "sorted", "reversed", "sawtooth", "nearlySorted"}
+	sizes := []int{0, 1, 2, 5, 31, 32, 33, 64, 100, 1000, 5000}
+	for _, pattern := range patterns {
+		for _, n := range sizes {
+			items := makeSortItems(n, pattern, r)
+			expected := make([]sortItem, n)
+			copy(expected, items)
+			sort.SliceStable(expected, func(i, j int) bool {
+				return expected[i].Key < expected[j].Key
+			})
+			result := y.Sort(items, func(a, b sortItem) bool {
+				return a.Key < b.Key
+			})
+			if !assert.Equal(t, expected, result, "pattern=%s n=%d", pattern, n) {
+				return
+			}
+		}
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	one, two := 1, 2
+	items := []sortItem{
+		{Key: 1, Name: "b", Score: &two, Index: 0},
+		{Key: 2, Name: "A", Score: nil, Index: 1},
+		{Key: 1, Name: "a", Score: &one, Index: 2},
+		{Key: 2, Name: "c", Score: &one, Index: 3},
+		{Key: 1, Name: "B", Score: nil, Index: 4},
+	}
+	indexes := func(items []sortItem) []int {
+		return y.Flex(items, func(v sortItem, i int) int {
+			return v.Index
+		})
+	}
+
+	result := y.SortBy(items,
+		y.Desc(func(v sortItem) int { return v.Key }),
+		y.AscFold(func(v sortItem) string { return v.Name }),
+	)
+	assert.Equal(t, []int{1, 3, 2, 0, 4}, indexes(result))
+
+	// 空值默认排在最后
+	result = y.SortBy(items, y.AscPtr(func(v sortItem) *int { return v.Score }))
+	assert.Equal(t, []int{2, 3, 0, 1, 4}, indexes(result))
+
+	result = y.SortBy(items, y.DescPtr(func(v sortItem) *int { return v.Score }).NullsFirst())
+	assert.Equal(t, []int{1, 4, 0, 2, 3}, indexes(result))
+
+	result = y.SortBy(items,
+		y.Asc(func(v sortItem) string { return v.Name }).NullIf(func(v sortItem) bool { return v.Name == "a" }).NullsFirst(),
+	)
+	assert.Equal(t, []int{2, 1, 4, 0, 3}, indexes(result))
+
+	// NullIf 不会取代 AscPtr 对 nil 的判断
+	result = y.SortBy(items, y.AscPtr(func(v sortItem) *int { return v.Score }).NullIf(func(v sortItem) bool { return v.Key == 2 }))
+	assert.Equal(t, []int{2, 0, 1, 3, 4}, indexes(result))
+
+	id := func(v string) string { return v }
+	assert.Equal(t, []string{"a", "ab", "B", "Éa", "éA"}, y.SortBy([]string{"Éa", "B", "ab", "éA", "a"}, y.AscFold(id)))
+	assert.Equal(t, []string{"Éa", "éA", "B", "ab", "a"}, y.SortBy([]string{"Éa", "B", "ab", "éA", "a"}, y.DescFold(id)))
+}
+
+func benchmarkSortItems(b *testing.B, pattern string) []sortItem {
+	b.Helper()
+	return makeSortItems(100000, pattern, rand.New(rand.NewSource(1)))
+}
+
+func BenchmarkSortRandom(b *testing.B) {
+	items := benchmarkSortItems(b, "random")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		y.Sort(items, func(a, b sortItem) bool { return a.Key < b.Key })
+	}
+}
+
+func BenchmarkSliceStableRandom(b *testing.B) {
+	items := benchmarkSortItems(b, "random")
+	cp := make([]sortItem, len(items))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(cp, items)
+		sort.SliceStable(cp, func(i, j int) bool { return cp[i].Key < cp[j].Key })
+	}
+}
+
+func BenchmarkSortNearlySorted(b *testing.B) {
+	items := benchmarkSortItems(b, "nearlySorted")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		y.Sort(items, func(a, b sortItem) bool { return a.Key < b.Key })
+	}
+}
+
+func BenchmarkSliceStableNearlySorted(b *testing.B) {
+	items := benchmarkSortItems(b, "nearlySorted")
+	cp := make([]sortItem, len(items))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(cp, items)
+		sort.SliceStable(cp, func(i, j int) bool { return cp[i].Key < cp[j].Key })
+	}
+}
+
+func BenchmarkSortByMultiKey(b *testing.B) {
+	items := benchmarkSortItems(b, "fewKeys")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		y.SortBy(items,
+			y.Asc(func(v sortItem) int { return v.Key }),
+			y.Desc(func(v sortItem) int { return v.Index }),
+		)
+	}
+}
+
+func BenchmarkSliceStableMultiKey(b *testing.B) {
+	items := benchmarkSortItems(b, "fewKeys")
+	cp := make([]sortItem, len(items))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(cp, items)
+		sort.SliceStable(cp, func(i, j int) bool {
+			if cp[i].Key != cp[j].Key {
+				return cp[i].Key < cp[j].Key
+			}
+			return cp[i].Index > cp[j].Index
+		})
+	}
+}
+
+func TestSortInPlace(t *testing.T) {
+	arr := []int{3, 1, 2}
+	result := y.Sort(&arr, func(a, b int) bool { return a < b })
+	assert.Equal(t, []int{1, 2, 3}, arr)
+	assert.Equal(t, []int{1, 2, 3}, result)
+
+	// 传入切片时不修改原切片
+	arr = []int{3, 1, 2}
+	result = y.Sort(arr, func(a, b int) bool { return a < b })
+	assert.Equal(t, []int{3, 1, 2}, arr)
+	assert.Equal(t, []int{1, 2, 3}, result)
+}
+
+func TestTopK(t *testing.T) {
+	arr := []int{5, 1, 9, 3, 7, 9, 2}
+	less := func(a, b int) bool { return a < b }
+	assert.Equal(t, []int{9, 9, 7}, y.TopK(arr, 3, less))
+	assert.Equal(t, []int{1, 2, 3}, y.BottomK(arr, 3, less))
+	assert.Equal(t, []int{9, 9, 7, 5, 3, 2, 1}, y.TopK(arr, 10, less))
+	assert.Equal(t, []int{}, y.TopK(arr, 0, less))
+	assert.Equal(t, []int{5, 1, 9, 3, 7, 9, 2}, arr)
+
+	// 相等元素保持原顺序
+	items := []sortItem{{Key: 1, Index: 0}, {Key: 2, Index: 1}, {Key: 2, Index: 2}, {Key: 2, Index: 3}}
+	top := y.TopK(items, 2, func(a, b sortItem) bool { return a.Key < b.Key })
+	assert.Equal(t, []sortItem{{Key: 2, Index: 1}, {Key: 2, Index: 2}}, top)
+}
+
+func TestNth(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	arr := make([]int, 1000)
+	for i := range arr {
+		arr[i] = r.Intn(500)
+	}
+	sorted := y.Sort(arr, func(a, b int) bool { return a < b })
+	for _, n := range []int{0, 1, 17, 500, 998, 999} {
+		v, ok := y.Nth(arr, n, func(a, b int) bool { return a < b })
+		assert.True(t, ok)
+		assert.Equal(t, sorted[n], v, "n=%d", n)
+	}
+	_, ok := y.Nth(arr, 1000, func(a, b int) bool { return a < b })
+	assert.False(t, ok)
+}
+
+func BenchmarkTopK(b *testing.B) {
+	items := benchmarkSortItems(b, "random")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		y.TopK(items, 10, func(a, b sortItem) bool { return a.Key < b.Key })
+	}
+}
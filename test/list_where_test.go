@@ -0,0 +1,71 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterWhere(t *testing.T) {
+	users := []user{
+		{ID: 1, Name: "LiLei", Age: 17},
+		{ID: 2, Name: "LiMing", Age: 20},
+		{ID: 3, Name: "HanMeimei", Age: 22},
+		{ID: 4, Name: "张三", Age: 30},
+	}
+
+	t.Run("结构体字段", func(t *testing.T) {
+		result := y.Filter(users, y.Where("Age>=18,Name*=Li"))
+		assert.Equal(t, []user{users[1]}, result)
+	})
+
+	t.Run("同一字段的多个条件", func(t *testing.T) {
+		// 去掉任意一个边界结果都会不同
+		assert.Equal(t, []user{users[1]}, y.Filter(users, y.Where("Age>=18,Age<21")))
+		assert.Equal(t, []user{users[1], users[2]}, y.Filter(users, y.Where("age>17,age<=22,Age!=30")))
+	})
+
+	t.Run("忽略大小写与方括号", func(t *testing.T) {
+		result := y.Filter(users, y.Where("[age>21,name*='张']"))
+		assert.Equal(t, []user{users[3]}, result)
+	})
+
+	t.Run("等于与不等于", func(t *testing.T) {
+		assert.Equal(t, []user{users[2]}, y.Filter(users, y.Where("id=3")))
+		assert.Len(t, y.Filter(users, y.Where("id!=3")), 3)
+	})
+
+	t.Run("字段不存在或数值非法", func(t *testing.T) {
+		assert.Empty(t, y.Filter(users, y.Where("Score>1")))
+		assert.Empty(t, y.Filter(users, y.Where("Age>abc")))
+	})
+
+	t.Run("指针与映射", func(t *testing.T) {
+		ptrs := []*user{&users[0], nil, &users[2]}
+		assert.Equal(t, []*user{&users[2]}, y.Filter(ptrs, y.Where("Age<=22,Age>17")))
+
+		rows := []map[string]any{
+			{"name": "a", "score": 60},
+			{"name": "b", "score": 90},
+			{"Name": "c", "Score": int32(95)},
+		}
+		result := y.Filter(rows, y.Where("score>=90"))
+		assert.Len(t, result, 2)
+		assert.Equal(t, "b", result[0]["name"])
+		assert.Equal(t, "c", result[1]["Name"])
+	})
+
+	t.Run("与其他条件组合", func(t *testing.T) {
+		w := y.Where("Age>=18")
+		kept, removed := y.Del(users, w)
+		assert.Equal(t, []user{users[0]}, kept)
+		assert.Equal(t, 3, removed)
+
+		yes, no := y.Partition(users, w)
+		assert.Len(t, yes, 3)
+		assert.Len(t, no, 1)
+
+		assert.Equal(t, 2, y.NewSeq(users).Filter(y.Where("Name*=Li")).Count())
+	})
+}
@@ -0,0 +1,45 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZip(t *testing.T) {
+	pairs := y.Zip([]int{1, 2, 3}, []string{"a", "b"})
+	assert.Equal(t, []y.Tuple2[int, string]{y.T(1, "a"), y.T(2, "b")}, pairs)
+
+	nums, strs := y.Unzip(pairs)
+	assert.Equal(t, []int{1, 2}, nums)
+	assert.Equal(t, []string{"a", "b"}, strs)
+
+	triples := y.Zip3([]int{1, 2}, []string{"a", "b"}, []bool{true, false})
+	assert.Equal(t, true, triples[0].Gamma())
+	a, b, c := y.Unzip3(triples)
+	assert.Equal(t, []int{1, 2}, a)
+	assert.Equal(t, []string{"a", "b"}, b)
+	assert.Equal(t, []bool{true, false}, c)
+}
+
+func TestEnumerateAndCrossJoin(t *testing.T) {
+	assert.Equal(t, []y.Tuple2[int, string]{y.T(0, "x"), y.T(1, "y")}, y.Enumerate([]string{"x", "y"}))
+
+	cross := y.CrossJoin([]int{1, 2}, []string{"a", "b"})
+	assert.Equal(t, []y.Tuple2[int, string]{y.T(1, "a"), y.T(1, "b"), y.T(2, "a"), y.T(2, "b")}, cross)
+	assert.Len(t, y.CrossJoin([]int{}, []string{"a"}), 0)
+}
+
+func TestFlexTuple(t *testing.T) {
+	result := y.Flex2(y.Zip([]int{1, 2, 2}, []string{"a", "b", "b"}), func(n int, s string, i int) string {
+		return fmt.Sprintf("%s%d", s, n)
+	}, y.UseDistinct)
+	assert.Equal(t, []string{"a1", "b2"}, result)
+
+	sums := y.Flex3(y.Zip3([]int{1, 2}, []int{10, 20}, []int{100, 200}), func(a, b, c int, i int) int {
+		return a + b + c
+	})
+	assert.Equal(t, []int{111, 222}, sums)
+}
@@ -0,0 +1,28 @@
+//go:build debug
+
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsupportedOptionDebug(t *testing.T) {
+	arr := []int{1, 2, 3}
+	assert.PanicsWithValue(t, "y.Flex: unsupported option WithTimeout", func() {
+		y.Flex(arr, func(v int, _ int) int { return v }, y.WithTimeout(time.Second))
+	})
+	assert.PanicsWithValue(t, "y.Find: unsupported option UseAsync", func() {
+		y.Find(arr, 2, y.UseAsync)
+	})
+	assert.PanicsWithValue(t, "y.Has: unsupported option UseAsync", func() {
+		y.Has(arr, 2, y.UseAsync)
+	})
+	assert.NotPanics(t, func() {
+		y.Filter(arr, y.Is, 1, 2)
+		y.Find(arr, 2, y.WithOffset(1), y.UseReverse)
+	})
+}
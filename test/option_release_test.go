@@ -0,0 +1,29 @@
+//go:build !debug
+
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsupportedOptionIgnored(t *testing.T) {
+	arr := []int{1, 2, 3}
+	assert.Equal(t, arr, y.Flex(arr, func(v int, _ int) int { return v }, y.WithTimeout(time.Second)))
+	v, ok := y.Find(arr, 2, y.UseAsync)
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+}
+
+func TestFilterUnsupportedOptionIgnored(t *testing.T) {
+	arr := []int{1, 2, 3}
+	assert.Equal(t, arr, y.Filter(arr, y.NotEmpty, y.WithLimit(2)))
+	assert.Equal(t, []int{2}, y.Filter(arr, y.Is, 2, y.WithTimeout(time.Second)))
+	hit, miss := y.Partition(arr, y.NotEmpty, y.WithLimit(2))
+	assert.Equal(t, arr, hit)
+	assert.Empty(t, miss)
+	assert.Equal(t, arr, y.NewSeq(arr).Filter(y.NotEmpty, y.WithLimit(2)).Collect())
+}
@@ -0,0 +1,84 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionInterface(t *testing.T) {
+	var opts = []y.Option{y.UseAsync, y.NotNil, y.WithLimit(2), y.WithOffset(1), y.WithTimeout(time.Second), y.WithKey(func(v int) any { return v })}
+	assert.Len(t, opts, 6)
+	assert.Equal(t, "UseAsync", y.UseAsync.String())
+	assert.Equal(t, []int{1, 2}, y.Flex([]int{1, 2, 2}, func(v int, _ int) int { return v }, []y.Option{y.UseDistinct}...))
+
+	// 新增的选项追加在末尾，不改变原有常量的取值
+	assert.Equal(t, 6, int(y.Is))
+	assert.Equal(t, 7, int(y.RMap))
+}
+
+func TestWithLimitFlex(t *testing.T) {
+	var running, peak int32
+	arr := make([]int, 20)
+	result := y.Flex(arr, func(_ int, i int) int {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return i
+	}, y.UseAsync, y.WithLimit(2))
+	assert.Len(t, result, 20)
+	assert.Equal(t, 19, result[19])
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+}
+
+func TestWithTimeout(t *testing.T) {
+	arr := []int{1, 2, 3, 4}
+	_, err := y.FlexCtx(context.Background(), arr, func(ctx context.Context, v int, _ int) (int, error) {
+		select {
+		case <-time.After(time.Second):
+			return v, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}, y.UseAsync, y.WithTimeout(10*time.Millisecond))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	result, err := y.FlexE(arr, func(v int, _ int) (int, error) {
+		return v * 2, nil
+	}, y.WithTimeout(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 4, 6, 8}, result)
+
+	var got []error
+	for r := range y.ParallelEach(context.Background(), arr, func(ctx context.Context, v int, _ int) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}, y.WithTimeout(10*time.Millisecond)) {
+		got = append(got, r.Err)
+	}
+	for _, err := range got {
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	}
+}
+
+func TestWithKey(t *testing.T) {
+	a := []user{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}
+	b := []user{{ID: 2, Name: "B"}, {ID: 3, Name: "c"}}
+	byID := y.WithKey(func(u user) any { return u.ID })
+
+	assert.Equal(t, []user{a[0], a[1], b[1]}, y.Union(a, b, byID))
+	assert.Equal(t, []user{a[1]}, y.Intersect(a, b, byID))
+	assert.Equal(t, []user{a[0]}, y.Distinct([]user{a[0], {ID: 1, Name: "x"}}, byID))
+	assert.Equal(t, 1, y.NewSeq([]user{a[0], {ID: 1}}).Distinct(byID).Count())
+}
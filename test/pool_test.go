@@ -0,0 +1,176 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/llyb120/yoya2/y"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPoolSubmit(t *testing.T) {
+	pool := y.NewWorkerPool(y.PoolOption{MinWorkers: 2, MaxWorkers: 2, QueueSize: 8})
+	defer pool.Shutdown(context.Background())
+
+	ctx := context.Background()
+	f := y.Submit(ctx, pool, func(ctx context.Context) (int, error) { return 42, nil })
+	v, err := f.Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, v)
+
+	f = y.Submit(ctx, pool, func(ctx context.Context) (int, error) { panic("boom") })
+	_, err = f.Await(ctx)
+	assert.True(t, strings.HasPrefix(err.Error(), "panic: boom"))
+}
+
+func TestWorkerPoolPriority(t *testing.T) {
+	pool := y.NewWorkerPool(y.PoolOption{MinWorkers: 1, MaxWorkers: 1, QueueSize: 8})
+	ctx := context.Background()
+
+	// 占住唯一的工作协程，让后面的任务排队
+	block := make(chan struct{})
+	started := make(chan struct{})
+	y.Submit(ctx, pool, func(ctx context.Context) (int, error) {
+		close(started)
+		<-block
+		return 0, nil
+	})
+	<-started
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) func(context.Context) (int, error) {
+		return func(context.Context) (int, error) {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return 0, nil
+		}
+	}
+	y.Submit(ctx, pool, record("low"), y.WithPriority(y.PriorityLow))
+	y.Submit(ctx, pool, record("normal"))
+	y.Submit(ctx, pool, record("high"), y.WithPriority(y.PriorityHigh))
+	close(block)
+
+	assert.NoError(t, pool.Shutdown(ctx))
+	assert.Equal(t, []string{"high", "normal", "low"}, order)
+}
+
+func TestWorkerPoolBackpressure(t *testing.T) {
+	pool := y.NewWorkerPool(y.PoolOption{MinWorkers: 1, MaxWorkers: 1, QueueSize: 1})
+	ctx := context.Background()
+	block := make(chan struct{})
+	started := make(chan struct{})
+	y.Submit(ctx, pool, func(ctx context.Context) (int, error) {
+		close(started)
+		<-block
+		return 0, nil
+	})
+	<-started
+	// 队列中放一个
+	y.Submit(ctx, pool, func(ctx context.Context) (int, error) { return 1, nil })
+
+	_, err := y.TrySubmit(ctx, pool, func(ctx context.Context) (int, error) { return 2, nil }).Await(ctx)
+	assert.True(t, errors.Is(err, y.ErrPoolFull))
+
+	tctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = y.Submit(tctx, pool, func(ctx context.Context) (int, error) { return 3, nil }).Await(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	close(block)
+	assert.NoError(t, pool.Shutdown(ctx))
+	_, err = y.Submit(ctx, pool, func(ctx context.Context) (int, error) { return 4, nil }).Await(ctx)
+	assert.True(t, errors.Is(err, y.ErrPoolClosed))
+}
+
+func TestWorkerPoolElastic(t *testing.T) {
+	pool := y.NewWorkerPool(y.PoolOption{MaxWorkers: 4, QueueSize: 16, IdleTimeout: 10 * time.Millisecond})
+	assert.Equal(t, 0, pool.Workers())
+
+	var running, peak int32
+	ctx := context.Background()
+	var futures []*y.Future[int]
+	for i := 0; i < 16; i++ {
+		futures = append(futures, y.Submit(ctx, pool, func(ctx context.Context) (int, error) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(2 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return 0, nil
+		}))
+	}
+	for _, f := range futures {
+		f.Await(ctx)
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(4))
+	assert.Eventually(t, func() bool { return pool.Workers() == 0 }, time.Second, 5*time.Millisecond)
+	assert.NoError(t, pool.Shutdown(ctx))
+}
+
+func TestWorkerPoolBurst(t *testing.T) {
+	pool := y.NewWorkerPool(y.PoolOption{MinWorkers: 1, MaxWorkers: 4, QueueSize: 4})
+	ctx := context.Background()
+	// 等常驻协程进入空闲状态
+	time.Sleep(10 * time.Millisecond)
+
+	var started int32
+	release := make(chan struct{})
+	var futures []*y.Future[int]
+	for i := 0; i < 4; i++ {
+		futures = append(futures, y.Submit(ctx, pool, func(ctx context.Context) (int, error) {
+			atomic.AddInt32(&started, 1)
+			<-release
+			return 0, nil
+		}))
+	}
+	// 一个空闲协程只能接走一个任务，其余的需要启动新的协程
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&started) == 4 }, time.Second, time.Millisecond)
+	assert.Equal(t, 4, pool.Workers())
+	close(release)
+	for _, f := range futures {
+		f.Await(ctx)
+	}
+	assert.NoError(t, pool.Shutdown(ctx))
+}
+
+func TestWorkerPoolShutdownDrains(t *testing.T) {
+	pool := y.NewWorkerPool(y.PoolOption{MaxWorkers: 2, QueueSize: 32})
+	ctx := context.Background()
+	var done int32
+	for i := 0; i < 20; i++ {
+		y.Submit(ctx, pool, func(ctx context.Context) (int, error) {
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&done, 1)
+			return 0, nil
+		})
+	}
+	assert.NoError(t, pool.Shutdown(ctx))
+	assert.Equal(t, int32(20), atomic.LoadInt32(&done))
+}
+
+func TestFlexWithPool(t *testing.T) {
+	pool := y.NewWorkerPool(y.PoolOption{MaxWorkers: 3})
+	defer pool.Shutdown(context.Background())
+
+	result := y.Flex([]int{1, 2, 3, 4}, func(v int, i int) int { return v * 10 }, y.WithPool(pool))
+	assert.Equal(t, []int{10, 20, 30, 40}, result)
+
+	flat := y.FlatFlex([]int{1, 2}, func(v int, i int) []int { return []int{v, v} }, y.WithPool(pool))
+	assert.Equal(t, []int{1, 1, 2, 2}, flat)
+
+	data := map[string]any{"a": map[string]any{"b": 1}, "c": 2}
+	picked := y.Pick[int](data, "b", "c", y.WithPool(pool))
+	assert.ElementsMatch(t, []int{1, 2}, picked)
+	assert.Equal(t, []int{1, 2}, y.Pick[int](data, "b", "c"))
+}
@@ -17,11 +17,46 @@ type lruEntry[K comparable, V any] struct {
 	size       uint64     // 条目占用的内存大小
 }
 
+// EvictReason 条目被移出缓存的原因
+type EvictReason int
+
+const (
+	EvictExpired  EvictReason = iota // 已过期
+	EvictCapacity                    // 超出 MaxSize
+	EvictMemory                      // 超出内存限制
+	EvictDeleted                     // 被 Del、Clear、Destroy 删除
+	EvictReplaced                    // 被新的 Set 覆盖
+)
+
+func (r EvictReason) String() string {
+	switch r {
+	case EvictExpired:
+		return "expired"
+	case EvictCapacity:
+		return "capacity"
+	case EvictMemory:
+		return "memory"
+	case EvictDeleted:
+		return "deleted"
+	case EvictReplaced:
+		return "replaced"
+	}
+	return "unknown"
+}
+
+type evictedEntry[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictReason
+}
+
 const (
 	// 默认清理比例，当内存达到限制时，清理20%的过期或最旧项目
 	defaultCleanupRatio = 0.2
 	// 最小清理数量
 	minCleanupCount = 1
+	// 后台清理时每次持有锁检查的条目数
+	janitorBatch = 128
 )
 
 type BaseCache[K comparable, V any] struct {
@@ -33,12 +68,33 @@ type BaseCache[K comparable, V any] struct {
 	currentMemory uint64              // 当前使用的内存（原子操作）
 	defaultTTL    time.Duration       // 默认过期时间，0表示永不过期
 	cleanupRatio  float64             // 清理比例
+	clock         Clock               // 时钟，测试中可以替换
+	stop          chan struct{}       // 关闭时停止后台清理
+	stopOnce      sync.Once
+	onEvict       func(key K, value V, reason EvictReason)
+	evicted       []evictedEntry[K, V] // 等待在锁外通知的条目
+	counters      *cacheCounters       // 单独分配，保证64位原子操作对齐
+	errorTTL      time.Duration        // 加载失败时错误的缓存时间
+	loading       map[K]*Future[V]     // 正在加载的键，Del、Clear、Set 会移除对应的加载，使其结果不再写入
+	loadErrs      map[K]loadError
+}
+
+// loadError 是被缓存的加载错误
+type loadError struct {
+	err        error
+	expireTime time.Time
 }
 
 type CacheOption struct {
 	MaxSize   int           // 最大条目数，0表示不限制
 	MaxMemory string        // 最大内存限制，支持 "10m", "1g" 等格式
 	TTL       time.Duration // 默认过期时间，0表示永不过期
+	// 后台清理过期条目的间隔，0表示不启动后台清理，过期条目只在访问或内存不足时删除
+	// 启动后需要调用 Destroy 停止，否则缓存不会被回收
+	CleanupInterval time.Duration
+	Clock           Clock // 时钟，nil 表示 SystemClock
+	// GetOrLoad 加载失败时缓存错误的时间，期间同一个键直接返回该错误而不再调用加载函数，0表示不缓存错误
+	ErrorTTL time.Duration
 }
 
 // parseMemory 解析内存大小字符串，如 "10m", "1g" 等
@@ -90,6 +146,18 @@ func NewBaseCache[K comparable, V any](opts CacheOption) *BaseCache[K, V] {
 		maxMemory:    maxMemory,
 		defaultTTL:   opts.TTL,
 		cleanupRatio: defaultCleanupRatio,
+		clock:        opts.Clock,
+		stop:         make(chan struct{}),
+		counters:     &cacheCounters{},
+		errorTTL:     opts.ErrorTTL,
+		loading:      make(map[K]*Future[V]),
+		loadErrs:     make(map[K]loadError),
+	}
+	if c.clock == nil {
+		c.clock = SystemClock
+	}
+	if opts.CleanupInterval > 0 {
+		go c.janitor(opts.CleanupInterval)
 	}
 
 	return c
@@ -102,7 +170,7 @@ func NewBaseCache[K comparable, V any](opts CacheOption) *BaseCache[K, V] {
 //	cache.Set(key, value, time.Hour)     // 指定TTL为1小时
 func (c *BaseCache[K, V]) Set(key K, value V, ttl ...time.Duration) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	defer c.unlock()
 
 	var ttlDuration time.Duration
 	if len(ttl) > 0 {
@@ -135,6 +203,9 @@ func calculateSize(v interface{}) uint64 {
 
 // setWithTTL 内部方法，设置带有TTL的缓存项
 func (c *BaseCache[K, V]) setWithTTL(key K, value V, ttl time.Duration) {
+	// 新写入的值优先于进行中的加载结果
+	delete(c.loading, key)
+
 	// 计算新条目大小
 	entrySize := calculateSize(key) + calculateSize(value)
 
@@ -146,10 +217,7 @@ func (c *BaseCache[K, V]) setWithTTL(key K, value V, ttl time.Duration) {
 
 	// 移除已存在的条目（如果存在）
 	if existingEntry, ok := c.cache[key]; ok {
-		oldEntry := existingEntry.Value.(*lruEntry[K, V])
-		c.ll.Remove(existingEntry)
-		atomic.AddUint64(&c.currentMemory, ^(oldEntry.size - 1)) // 减去旧条目大小
-		delete(c.cache, key)
+		c.removeElement(existingEntry, EvictReplaced)
 	}
 
 	// 检查是否需要清理
@@ -163,17 +231,14 @@ func (c *BaseCache[K, V]) setWithTTL(key K, value V, ttl time.Duration) {
 	if c.maxMemory > 0 && atomic.LoadUint64(&c.currentMemory)+entrySize > c.maxMemory && c.ll.Len() > 0 {
 		back := c.ll.Back()
 		if back != nil {
-			entry := back.Value.(*lruEntry[K, V])
-			delete(c.cache, entry.key)
-			c.ll.Remove(back)
-			atomic.AddUint64(&c.currentMemory, ^(entry.size - 1))
+			c.removeElement(back, EvictMemory)
 		}
 	}
 
 	// 添加新条目
 	var expireTime *time.Time
 	if ttl > 0 {
-		expire := time.Now().Add(ttl)
+		expire := c.clock.Now().Add(ttl)
 		expireTime = &expire
 	}
 
@@ -193,17 +258,14 @@ func (c *BaseCache[K, V]) setWithTTL(key K, value V, ttl time.Duration) {
 		if back == nil {
 			break
 		}
-		entry := back.Value.(*lruEntry[K, V])
-		delete(c.cache, entry.key)
-		c.ll.Remove(back)
-		atomic.AddUint64(&c.currentMemory, ^(entry.size - 1))
+		c.removeElement(back, EvictCapacity)
 	}
 }
 
 // SetMap 批量设置缓存项
 func (c *BaseCache[K, V]) SetMap(m map[K]V) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	defer c.unlock()
 	for key, value := range m {
 		c.setWithTTL(key, value, c.defaultTTL)
 	}
@@ -230,6 +292,7 @@ func (c *BaseCache[K, V]) get(key K) (V, bool) {
 	c.mu.RUnlock()
 
 	if !ok {
+		atomic.AddUint64(&c.counters.misses, 1)
 		var zero V
 		return zero, false
 	}
@@ -237,16 +300,15 @@ func (c *BaseCache[K, V]) get(key K) (V, bool) {
 	item := entry.Value.(*lruEntry[K, V])
 
 	// 检查是否过期
-	now := time.Now()
+	now := c.clock.Now()
 	if item.expireTime != nil && now.After(*item.expireTime) {
+		atomic.AddUint64(&c.counters.misses, 1)
 		c.mu.Lock()
 		// 再次检查，防止并发问题
 		if e, ok := c.cache[key]; ok && e == entry {
-			c.ll.Remove(entry)
-			delete(c.cache, key)
-			atomic.AddUint64(&c.currentMemory, ^(item.size - 1))
+			c.removeElement(entry, EvictExpired)
 		}
-		c.mu.Unlock()
+		c.unlock()
 		var zero V
 		return zero, false
 	}
@@ -254,6 +316,7 @@ func (c *BaseCache[K, V]) get(key K) (V, bool) {
 	c.mu.Lock()
 	c.ll.MoveToFront(entry) // 移动到前面表示最近使用
 	c.mu.Unlock()
+	atomic.AddUint64(&c.counters.hits, 1)
 
 	return item.value, true
 }
@@ -269,11 +332,9 @@ func (c *BaseCache[K, V]) getLocked(key K) (V, bool) {
 	item := entry.Value.(*lruEntry[K, V])
 
 	// 检查是否过期（持写锁可直接删除）
-	now := time.Now()
+	now := c.clock.Now()
 	if item.expireTime != nil && now.After(*item.expireTime) {
-		c.ll.Remove(entry)
-		delete(c.cache, key)
-		atomic.AddUint64(&c.currentMemory, ^(item.size - 1))
+		c.removeElement(entry, EvictExpired)
 		var zero V
 		return zero, false
 	}
@@ -282,22 +343,33 @@ func (c *BaseCache[K, V]) getLocked(key K) (V, bool) {
 	return item.value, true
 }
 
+// Del 删除缓存项，同时清除这些键被缓存的加载错误，进行中的加载结果也不会再写入
 func (c *BaseCache[K, V]) Del(key ...K) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 	for _, k := range key {
 		if entry, ok := c.cache[k]; ok {
-			c.ll.Remove(entry)
-			delete(c.cache, k)
+			c.removeElement(entry, EvictDeleted)
 		}
+		delete(c.loading, k)
+		delete(c.loadErrs, k)
 	}
+	c.unlock()
 }
 
 func (c *BaseCache[K, V]) Clear() {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	defer c.unlock()
+	c.loading = make(map[K]*Future[V])
+	c.loadErrs = make(map[K]loadError)
+	if c.onEvict != nil {
+		for e := c.ll.Back(); e != nil; e = e.Prev() {
+			entry := e.Value.(*lruEntry[K, V])
+			c.evicted = append(c.evicted, evictedEntry[K, V]{entry.key, entry.value, EvictDeleted})
+		}
+	}
 	c.cache = make(map[K]*list.Element)
 	c.ll.Init() // Clear the list
+	atomic.StoreUint64(&c.currentMemory, 0)
 }
 
 // maybeCleanup 在需要时清理过期或最旧的项目
@@ -308,9 +380,9 @@ func (c *BaseCache[K, V]) maybeCleanup() {
 	}
 
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	defer c.unlock()
 
-	now := time.Now()
+	now := c.clock.Now()
 	removed := 0
 	targetCount := int(float64(c.ll.Len()) * c.cleanupRatio)
 	if targetCount < minCleanupCount {
@@ -323,18 +395,16 @@ func (c *BaseCache[K, V]) maybeCleanup() {
 		next := e.Prev()
 
 		// 如果项目已过期或需要释放内存，则删除
-		if (entry.expireTime != nil && now.After(*entry.expireTime)) ||
-			atomic.LoadUint64(&c.currentMemory) > c.maxMemory {
-			delete(c.cache, entry.key)
-			c.ll.Remove(e)
-			atomic.AddUint64(&c.currentMemory, ^(entry.size - 1))
+		if entry.expireTime != nil && now.After(*entry.expireTime) {
+			c.removeElement(e, EvictExpired)
+			removed++
+		} else if atomic.LoadUint64(&c.currentMemory) > c.maxMemory {
+			c.removeElement(e, EvictMemory)
 			removed++
 		} else if removed == 0 {
 			// 如果第一个项目未过期，且内存仍然超限，强制清理最旧的项目
 			if atomic.LoadUint64(&c.currentMemory) > c.maxMemory {
-				delete(c.cache, entry.key)
-				c.ll.Remove(e)
-				atomic.AddUint64(&c.currentMemory, ^(entry.size - 1))
+				c.removeElement(e, EvictMemory)
 				removed++
 			} else {
 				// 内存已足够，退出循环
@@ -350,7 +420,7 @@ func (c *BaseCache[K, V]) maybeCleanup() {
 // 注意：推荐使用 Set(key, value, ttl) 替代
 func (c *BaseCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	defer c.unlock()
 	c.setWithTTL(key, value, ttl)
 }
 
@@ -372,7 +442,7 @@ func (c *BaseCache[K, V]) SetMemoryLimit(limit string) error {
 	}
 
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	defer c.unlock()
 
 	c.maxMemory = size
 
@@ -382,37 +452,233 @@ func (c *BaseCache[K, V]) SetMemoryLimit(limit string) error {
 		if back == nil {
 			break
 		}
-		entry := back.Value.(*lruEntry[K, V])
-		delete(c.cache, entry.key)
-		c.ll.Remove(back)
-		atomic.AddUint64(&c.currentMemory, ^(entry.size - 1))
+		c.removeElement(back, EvictMemory)
 	}
 
 	return nil
 }
 
+// Destroy 停止后台清理并清空缓存，可以重复调用
 func (c *BaseCache[K, V]) Destroy() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+	// Clear 自己会加锁，这里不能再持有锁
 	c.Clear()
 }
 
-// GetOrSetFunc 获取或设置缓存项，如果不存在则调用函数生成值
-func (c *BaseCache[K, V]) GetOrSetFunc(key K, fn func() V) V {
-	value, ok := c.Get(key)
-	if !ok {
+// janitor 每隔 interval 清理一次过期条目，直到 Destroy
+func (c *BaseCache[K, V]) janitor(interval time.Duration) {
+	for {
+		select {
+		case <-c.clock.After(interval):
+			c.DeleteExpired()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// DeleteExpired 删除所有已过期的条目并返回删除的个数
+// 先在读锁下找出已过期的条目，再分批在写锁下删除，删除前会确认条目未被替换且仍已过期
+func (c *BaseCache[K, V]) DeleteExpired() int {
+	c.mu.RLock()
+	now := c.clock.Now()
+	var expired []*list.Element
+	for e := c.ll.Back(); e != nil; e = e.Prev() {
+		entry := e.Value.(*lruEntry[K, V])
+		if entry.expireTime != nil && now.After(*entry.expireTime) {
+			expired = append(expired, e)
+		}
+	}
+	c.mu.RUnlock()
+
+	removed := 0
+	for len(expired) > 0 {
+		batch := expired
+		if len(batch) > janitorBatch {
+			batch = batch[:janitorBatch]
+		}
+		expired = expired[len(batch):]
+
 		c.mu.Lock()
-		defer c.mu.Unlock()
-		if value, ok = c.getLocked(key); ok {
-			return value
+		now := c.clock.Now()
+		for _, e := range batch {
+			entry := e.Value.(*lruEntry[K, V])
+			// 两批之间条目可能已被删除、替换或重新设置了过期时间
+			if c.cache[entry.key] != e || entry.expireTime == nil || !now.After(*entry.expireTime) {
+				continue
+			}
+			c.removeElement(e, EvictExpired)
+			removed++
+		}
+		c.unlock()
+	}
+
+	c.mu.Lock()
+	now = c.clock.Now()
+	for key, e := range c.loadErrs {
+		if now.After(e.expireTime) {
+			delete(c.loadErrs, key)
 		}
-		value = fn()
-		c.setWithTTL(key, value, c.defaultTTL)
-		return value
+	}
+	c.mu.Unlock()
+	return removed
+}
+
+// GetOrSetFunc 获取或设置缓存项，如果不存在则调用函数生成值
+// 加载过程同 GetOrLoad，fn 的 panic 会以原始值重新 panic，等待同一次加载的其他调用方则 panic 带调用栈的错误
+func (c *BaseCache[K, V]) GetOrSetFunc(key K, fn func() V) V {
+	var recovered any
+	panicked := false
+	value, err := c.GetOrLoad(key, func() (V, error) {
+		defer func() {
+			if r := recover(); r != nil {
+				recovered, panicked = r, true
+				panic(r)
+			}
+		}()
+		return fn(), nil
+	})
+	if panicked {
+		panic(recovered)
+	}
+	if err != nil {
+		panic(err)
 	}
 	return value
 }
 
+// GetOrLoad 获取缓存项，不存在时调用 fn 加载并写入缓存
+// 同一个键的并发加载只会调用一次 fn，其他调用方等待并共享结果；加载期间不持有缓存的锁，不影响其他键的读写
+// 加载期间该键被 Del、Clear 或 Set 时，加载结果只返回给调用方，不再写入缓存
+// fn 返回错误时不写入缓存，设置了 ErrorTTL 时错误会被缓存，到期或 Del 之前直接返回该错误
+// fn 的 panic 会像 Try 一样转换为带调用栈的错误
+//
+//	user, err := cache.GetOrLoad(id, func() (*User, error) {
+//		return db.FindUser(id)
+//	})
+func (c *BaseCache[K, V]) GetOrLoad(key K, fn func() (V, error)) (V, error) {
+	if value, ok := c.Get(key); ok {
+		return value, nil
+	}
+
+	c.mu.Lock()
+	// 在 Get 与加锁之间，上一次加载可能刚刚完成
+	if value, ok := c.getLocked(key); ok {
+		c.unlock()
+		return value, nil
+	}
+	if e, ok := c.loadErrs[key]; ok {
+		if !c.clock.Now().After(e.expireTime) {
+			c.unlock()
+			return *new(V), e.err
+		}
+		delete(c.loadErrs, key)
+	}
+	if f, ok := c.loading[key]; ok {
+		c.unlock()
+		<-f.done
+		return f.value, f.err
+	}
+	f := newFuture[V]()
+	c.loading[key] = f
+	c.unlock()
+
+	value, err := c.load(fn)
+
+	// 写入与结束加载在同一次加锁中完成，之后的调用方一定能读到结果
+	c.mu.Lock()
+	if c.loading[key] == f {
+		delete(c.loading, key)
+		if err == nil {
+			c.setWithTTL(key, value, c.defaultTTL)
+		} else if c.errorTTL > 0 {
+			c.loadErrs[key] = loadError{err: err, expireTime: c.clock.Now().Add(c.errorTTL)}
+		}
+	}
+	c.unlock()
+	f.complete(value, err)
+	return value, err
+}
+
+// load 调用 fn 并记录加载次数、耗时与失败次数，fn 的 panic 会转换为错误
+func (c *BaseCache[K, V]) load(fn func() (V, error)) (V, error) {
+	var value V
+	var err error
+	start := time.Now()
+	if perr := Try(func() { value, err = fn() }); perr != nil {
+		err = perr
+	}
+	counters := c.counters
+	atomic.AddUint64(&counters.loads, 1)
+	atomic.AddUint64(&counters.loadNanos, uint64(time.Since(start)))
+	if err != nil {
+		atomic.AddUint64(&counters.loadErrors, 1)
+		return *new(V), err
+	}
+	return value, nil
+}
+
+// OnEvict 设置条目被移出缓存时的回调，回调在锁外调用，可以安全地访问缓存
+// 同一次操作移出的多个条目按移出顺序通知，不同协程之间的通知顺序不做保证
+//
+//	cache.OnEvict(func(key string, conn *Conn, reason y.EvictReason) {
+//		conn.Close()
+//	})
+func (c *BaseCache[K, V]) OnEvict(fn func(key K, value V, reason EvictReason)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onEvict = fn
+}
+
+// removeElement 删除条目并记录移出原因，需要持有写锁
+func (c *BaseCache[K, V]) removeElement(e *list.Element, reason EvictReason) {
+	entry := e.Value.(*lruEntry[K, V])
+	c.ll.Remove(e)
+	delete(c.cache, entry.key)
+	atomic.AddUint64(&c.currentMemory, ^(entry.size - 1))
+	switch reason {
+	case EvictExpired:
+		atomic.AddUint64(&c.counters.expirations, 1)
+	case EvictCapacity, EvictMemory:
+		atomic.AddUint64(&c.counters.evictions, 1)
+	}
+	if c.onEvict != nil {
+		c.evicted = append(c.evicted, evictedEntry[K, V]{entry.key, entry.value, reason})
+	}
+}
+
+// unlock 释放写锁，并在锁外通知持有锁期间移出的条目
+func (c *BaseCache[K, V]) unlock() {
+	evicted, onEvict := c.evicted, c.onEvict
+	c.evicted = nil
+	c.mu.Unlock()
+	if onEvict == nil {
+		return
+	}
+	for _, e := range evicted {
+		onEvict(e.key, e.value, e.reason)
+	}
+}
+
+// Stats 返回统计信息的快照
+func (c *BaseCache[K, V]) Stats() CacheStats {
+	s := c.counters.snapshot()
+	s.Entries = c.Len()
+	s.MemoryUsage = c.MemoryUsage()
+	return s
+}
+
+// ResetStats 将计数清零并返回清零前的快照
+// 每个计数通过原子交换清零，并发的更新要么计入返回的快照，要么计入清零后的计数，不会丢失
+func (c *BaseCache[K, V]) ResetStats() CacheStats {
+	s := c.counters.reset()
+	s.Entries = c.Len()
+	s.MemoryUsage = c.MemoryUsage()
+	return s
+}
+
 // Len returns the number of items in the cache.
 func (c *BaseCache[K, V]) Len() int {
 	c.mu.RLock()
@@ -0,0 +1,271 @@
+package y
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// minShardMemory 每个分片至少分到的内存限制，太小的分片放不下一个条目，Set 会直接丢弃
+const minShardMemory = 1 << 10
+
+// ShardedCache 将键按哈希分散到多个相互独立的 BaseCache 分片中，每个分片有自己的锁，
+// 读写不同分片的键不会互相阻塞，适合高并发读的场景
+// MaxSize、MaxMemory 为所有分片的总和，平均分配给每个分片（余数分给前面的分片，总和与设置的值一致），LRU 淘汰在分片内部进行
+type ShardedCache[K comparable, V any] struct {
+	shards []*BaseCache[K, V]
+	mask   uint64
+	hasher Hasher[K]
+}
+
+// NewShardedCache 创建分片缓存，shards 会向上取整为2的幂，shards <= 0 时使用 runtime.GOMAXPROCS(0)*4
+// MaxSize 小于分片数、或者 MaxMemory 平均到每个分片不足 1k 时，分片数会减半直到满足为止
+// 可以通过 hasher 指定键的哈希函数，默认对字符串使用 FNV-1a、对整数直接打散，其他类型使用结构化哈希 Hash
+func NewShardedCache[K comparable, V any](shards int, opts CacheOption, hasher ...Hasher[K]) *ShardedCache[K, V] {
+	if shards <= 0 {
+		shards = runtime.GOMAXPROCS(0) * 4
+	}
+	n := 1
+	for n < shards {
+		n <<= 1
+	}
+
+	maxMemory, err := parseMemory(opts.MaxMemory)
+	if err != nil {
+		panic("invalid max memory format: " + err.Error())
+	}
+	for n > 1 && opts.MaxSize > 0 && opts.MaxSize < n {
+		n >>= 1
+	}
+	for n > 1 && maxMemory > 0 && maxMemory/uint64(n) < minShardMemory {
+		n >>= 1
+	}
+
+	c := &ShardedCache[K, V]{
+		shards: make([]*BaseCache[K, V], n),
+		mask:   uint64(n - 1),
+		hasher: defaultShardHasher[K],
+	}
+	if len(hasher) > 0 && hasher[0] != nil {
+		c.hasher = hasher[0]
+	}
+	for i := range c.shards {
+		shardOpts := opts
+		if opts.MaxSize > 0 {
+			shardOpts.MaxSize = int(splitLimit(uint64(opts.MaxSize), n, i))
+		}
+		if maxMemory > 0 {
+			shardOpts.MaxMemory = strconv.FormatUint(splitLimit(maxMemory, n, i), 10)
+		}
+		c.shards[i] = NewBaseCache[K, V](shardOpts)
+	}
+	return c
+}
+
+// splitLimit 返回 total 平均分成 n 份后第 i 份的大小，余数分给前面的分片，各份之和等于 total
+func splitLimit(total uint64, n int, i int) uint64 {
+	size := total / uint64(n)
+	if uint64(i) < total%uint64(n) {
+		size++
+	}
+	return size
+}
+
+// defaultShardHasher 对常见的键类型做快速哈希，避免反射
+func defaultShardHasher[K comparable](key K) uint64 {
+	switch k := any(key).(type) {
+	case string:
+		return fnvString(k)
+	case int:
+		return mix64(uint64(k))
+	case int32:
+		return mix64(uint64(k))
+	case int64:
+		return mix64(uint64(k))
+	case uint:
+		return mix64(uint64(k))
+	case uint32:
+		return mix64(uint64(k))
+	case uint64:
+		return mix64(k)
+	default:
+		return Hash(key)
+	}
+}
+
+func fnvString(s string) uint64 {
+	h := uint64(fnvOffset64)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= fnvPrime64
+	}
+	return h
+}
+
+// mix64 打散整数键的低位，避免连续的整数集中在少数分片
+func mix64(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+func (c *ShardedCache[K, V]) shard(key K) *BaseCache[K, V] {
+	return c.shards[c.hasher(key)&c.mask]
+}
+
+// Set 设置缓存项，可以指定可选的TTL，语义同 BaseCache.Set
+func (c *ShardedCache[K, V]) Set(key K, value V, ttl ...time.Duration) {
+	c.shard(key).Set(key, value, ttl...)
+}
+
+// SetWithTTL 设置带有过期时间的缓存项
+func (c *ShardedCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	c.shard(key).SetWithTTL(key, value, ttl)
+}
+
+// SetMap 批量设置缓存项，按分片分组后每个分片只加一次锁
+func (c *ShardedCache[K, V]) SetMap(m map[K]V) {
+	groups := make(map[*BaseCache[K, V]]map[K]V)
+	for key, value := range m {
+		shard := c.shard(key)
+		group, ok := groups[shard]
+		if !ok {
+			group = make(map[K]V)
+			groups[shard] = group
+		}
+		group[key] = value
+	}
+	for shard, group := range groups {
+		shard.SetMap(group)
+	}
+}
+
+func (c *ShardedCache[K, V]) Get(key K) (V, bool) {
+	return c.shard(key).Get(key)
+}
+
+func (c *ShardedCache[K, V]) Gets(keys ...K) []V {
+	values := make([]V, 0, len(keys))
+	for _, key := range keys {
+		if value, ok := c.shard(key).Get(key); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func (c *ShardedCache[K, V]) Del(key ...K) {
+	for _, k := range key {
+		c.shard(k).Del(k)
+	}
+}
+
+// GetOrSetFunc 获取或设置缓存项，语义同 BaseCache.GetOrSetFunc
+func (c *ShardedCache[K, V]) GetOrSetFunc(key K, fn func() V) V {
+	return c.shard(key).GetOrSetFunc(key, fn)
+}
+
+// GetOrLoad 获取或加载缓存项，语义同 BaseCache.GetOrLoad
+func (c *ShardedCache[K, V]) GetOrLoad(key K, fn func() (V, error)) (V, error) {
+	return c.shard(key).GetOrLoad(key, fn)
+}
+
+func (c *ShardedCache[K, V]) Clear() {
+	for _, shard := range c.shards {
+		shard.Clear()
+	}
+}
+
+func (c *ShardedCache[K, V]) Destroy() {
+	for _, shard := range c.shards {
+		shard.Destroy()
+	}
+}
+
+// OnEvict 为所有分片设置移出回调，语义同 BaseCache.OnEvict
+func (c *ShardedCache[K, V]) OnEvict(fn func(key K, value V, reason EvictReason)) {
+	for _, shard := range c.shards {
+		shard.OnEvict(fn)
+	}
+}
+
+// Stats 返回所有分片汇总后的统计信息
+func (c *ShardedCache[K, V]) Stats() CacheStats {
+	var s CacheStats
+	for _, shard := range c.shards {
+		s = s.add(shard.Stats())
+	}
+	return s
+}
+
+// ResetStats 将所有分片的计数清零并返回汇总的清零前快照
+func (c *ShardedCache[K, V]) ResetStats() CacheStats {
+	var s CacheStats
+	for _, shard := range c.shards {
+		s = s.add(shard.ResetStats())
+	}
+	return s
+}
+
+// Len 返回所有分片的条目总数
+func (c *ShardedCache[K, V]) Len() int {
+	n := 0
+	for _, shard := range c.shards {
+		n += shard.Len()
+	}
+	return n
+}
+
+// Cap 返回所有分片的容量总和
+func (c *ShardedCache[K, V]) Cap() int {
+	n := 0
+	for _, shard := range c.shards {
+		n += shard.Cap()
+	}
+	return n
+}
+
+// Shards 返回分片数
+func (c *ShardedCache[K, V]) Shards() int {
+	return len(c.shards)
+}
+
+// MemoryUsage 返回所有分片使用的内存总和（字节）
+func (c *ShardedCache[K, V]) MemoryUsage() uint64 {
+	var n uint64
+	for _, shard := range c.shards {
+		n += shard.MemoryUsage()
+	}
+	return n
+}
+
+// MemoryLimit 返回所有分片的内存限制总和（字节）
+func (c *ShardedCache[K, V]) MemoryLimit() uint64 {
+	var n uint64
+	for _, shard := range c.shards {
+		n += shard.MemoryLimit()
+	}
+	return n
+}
+
+// SetMemoryLimit 设置总的内存限制，平均分配给每个分片，平均后每个分片不足 1k 时返回错误
+func (c *ShardedCache[K, V]) SetMemoryLimit(limit string) error {
+	size, err := parseMemory(limit)
+	if err != nil {
+		return err
+	}
+	n := len(c.shards)
+	if size > 0 && size/uint64(n) < minShardMemory {
+		return fmt.Errorf("memory limit %s is too small for %d shards, need at least %d bytes", limit, n, uint64(n)*minShardMemory)
+	}
+	for i, shard := range c.shards {
+		if err := shard.SetMemoryLimit(strconv.FormatUint(splitLimit(size, n, i), 10)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
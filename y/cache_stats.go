@@ -0,0 +1,221 @@
+package y
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// CacheStats 是缓存统计信息的快照
+type CacheStats struct {
+	Hits        uint64        // 命中次数
+	Misses      uint64        // 未命中次数（包括已过期）
+	Evictions   uint64        // 因超出 MaxSize 或内存限制被淘汰的条目数
+	Expirations uint64        // 因过期被删除的条目数
+	Loads       uint64        // GetOrLoad、GetOrSetFunc 调用加载函数的次数
+	LoadErrors  uint64        // 加载函数返回错误或 panic 的次数
+	LoadTime    time.Duration // 加载函数的总耗时
+	Entries     int           // 当前条目数
+	MemoryUsage uint64        // 当前使用的内存（字节）
+}
+
+// HitRate 返回命中率，没有访问时为0
+func (s CacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// AvgLoadTime 返回平均加载耗时
+func (s CacheStats) AvgLoadTime() time.Duration {
+	if s.Loads == 0 {
+		return 0
+	}
+	return s.LoadTime / time.Duration(s.Loads)
+}
+
+// add 累加另一个快照，用于汇总多个缓存
+func (s CacheStats) add(o CacheStats) CacheStats {
+	s.Hits += o.Hits
+	s.Misses += o.Misses
+	s.Evictions += o.Evictions
+	s.Expirations += o.Expirations
+	s.Loads += o.Loads
+	s.LoadErrors += o.LoadErrors
+	s.LoadTime += o.LoadTime
+	s.Entries += o.Entries
+	s.MemoryUsage += o.MemoryUsage
+	return s
+}
+
+// cacheCounters 保存累计的计数，所有字段只通过原子操作访问
+type cacheCounters struct {
+	hits        uint64
+	misses      uint64
+	evictions   uint64
+	expirations uint64
+	loads       uint64
+	loadErrors  uint64
+	loadNanos   uint64
+}
+
+func (c *cacheCounters) snapshot() CacheStats {
+	return CacheStats{
+		Hits:        atomic.LoadUint64(&c.hits),
+		Misses:      atomic.LoadUint64(&c.misses),
+		Evictions:   atomic.LoadUint64(&c.evictions),
+		Expirations: atomic.LoadUint64(&c.expirations),
+		Loads:       atomic.LoadUint64(&c.loads),
+		LoadErrors:  atomic.LoadUint64(&c.loadErrors),
+		LoadTime:    time.Duration(atomic.LoadUint64(&c.loadNanos)),
+	}
+}
+
+// reset 逐个原子地交换为0，返回交换前的值
+func (c *cacheCounters) reset() CacheStats {
+	return CacheStats{
+		Hits:        atomic.SwapUint64(&c.hits, 0),
+		Misses:      atomic.SwapUint64(&c.misses, 0),
+		Evictions:   atomic.SwapUint64(&c.evictions, 0),
+		Expirations: atomic.SwapUint64(&c.expirations, 0),
+		Loads:       atomic.SwapUint64(&c.loads, 0),
+		LoadErrors:  atomic.SwapUint64(&c.loadErrors, 0),
+		LoadTime:    time.Duration(atomic.SwapUint64(&c.loadNanos, 0)),
+	}
+}
+
+// CacheStatsProvider 是可以提供统计信息的缓存，BaseCache 与 ShardedCache 都实现了它
+type CacheStatsProvider interface {
+	Stats() CacheStats
+}
+
+// MetricsExporter 是导出指标的适配器，可以对接 Prometheus 等监控系统
+// labels 可以为 nil
+type MetricsExporter interface {
+	Counter(name, help string, value float64, labels map[string]string)
+	Gauge(name, help string, value float64, labels map[string]string)
+}
+
+// ExportCacheStats 将缓存统计信息以 cache_ 开头的指标导出，并附加 cache=name 标签
+func ExportCacheStats(e MetricsExporter, name string, p CacheStatsProvider) {
+	s := p.Stats()
+	labels := map[string]string{"cache": name}
+	e.Counter("cache_hits_total", "Number of cache hits.", float64(s.Hits), labels)
+	e.Counter("cache_misses_total", "Number of cache misses, including expired entries.", float64(s.Misses), labels)
+	e.Counter("cache_evictions_total", "Number of entries evicted by size or memory limits.", float64(s.Evictions), labels)
+	e.Counter("cache_expirations_total", "Number of entries removed after expiring.", float64(s.Expirations), labels)
+	e.Counter("cache_loads_total", "Number of loader calls.", float64(s.Loads), labels)
+	e.Counter("cache_load_errors_total", "Number of loader calls that returned an error.", float64(s.LoadErrors), labels)
+	e.Counter("cache_load_seconds_total", "Total time spent in loader calls.", s.LoadTime.Seconds(), labels)
+	e.Gauge("cache_entries", "Current number of entries.", float64(s.Entries), labels)
+	e.Gauge("cache_memory_bytes", "Estimated memory used by entries.", float64(s.MemoryUsage), labels)
+}
+
+// PrometheusExporter 收集指标并按 Prometheus 文本格式输出，不依赖网络
+//
+//	e := y.NewPrometheusExporter()
+//	y.ExportCacheStats(e, "users", userCache)
+//	y.ExportCacheStats(e, "orders", orderCache)
+//	e.WriteTo(w)
+type PrometheusExporter struct {
+	families []*metricFamily
+	index    map[string]*metricFamily
+}
+
+type metricFamily struct {
+	name    string
+	help    string
+	typ     string
+	samples []metricSample
+}
+
+type metricSample struct {
+	labels string
+	value  float64
+}
+
+func NewPrometheusExporter() *PrometheusExporter {
+	return &PrometheusExporter{index: make(map[string]*metricFamily)}
+}
+
+func (e *PrometheusExporter) Counter(name, help string, value float64, labels map[string]string) {
+	e.add(name, help, "counter", value, labels)
+}
+
+func (e *PrometheusExporter) Gauge(name, help string, value float64, labels map[string]string) {
+	e.add(name, help, "gauge", value, labels)
+}
+
+func (e *PrometheusExporter) add(name, help, typ string, value float64, labels map[string]string) {
+	f, ok := e.index[name]
+	if !ok {
+		f = &metricFamily{name: name, help: help, typ: typ}
+		e.index[name] = f
+		e.families = append(e.families, f)
+	}
+	f.samples = append(f.samples, metricSample{labels: formatLabels(labels), value: value})
+}
+
+// WriteTo 按添加顺序输出所有指标，同名指标的 HELP、TYPE 只输出一次
+func (e *PrometheusExporter) WriteTo(w io.Writer) (int64, error) {
+	cw := &countWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	for _, f := range e.families {
+		fmt.Fprintf(bw, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+		fmt.Fprintf(bw, "# TYPE %s %s\n", f.name, f.typ)
+		for _, s := range f.samples {
+			fmt.Fprintf(bw, "%s%s %s\n", f.name, s.labels, strconv.FormatFloat(s.value, 'g', -1, 64))
+		}
+	}
+	err := bw.Flush()
+	return cw.n, err
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// formatLabels 按键排序输出 {k="v",...}，没有标签时为空字符串
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(k)
+		b.WriteString(`="`)
+		b.WriteString(labelEscaper.Replace(labels[k]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,17 @@
+package y
+
+import "fmt"
+
+// IndexError 记录列表处理中出错元素的下标
+type IndexError struct {
+	Index int
+	Err   error
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("index %d: %v", e.Index, e.Err)
+}
+
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,140 @@
+package y
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Backoff 根据已经失败的次数（从1开始）计算下一次重试前的等待时间
+type Backoff func(attempt int) time.Duration
+
+// FixedBackoff 每次等待固定的时间
+func FixedBackoff(d time.Duration) Backoff {
+	return func(int) time.Duration {
+		return d
+	}
+}
+
+// ExponentialBackoff 等待时间从 base 开始每次翻倍，最多为 maxDelay，maxDelay <= 0 表示不限制
+func ExponentialBackoff(base, maxDelay time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		d := base
+		for i := 1; i < attempt; i++ {
+			if maxDelay > 0 && d >= maxDelay {
+				break
+			}
+			if d > math.MaxInt64/2 {
+				d = math.MaxInt64
+				break
+			}
+			d *= 2
+		}
+		if maxDelay > 0 && d > maxDelay {
+			return maxDelay
+		}
+		return d
+	}
+}
+
+// JitterBackoff 在 b 的基础上加入随机抖动，实际等待时间在 [d/2, d) 之间，避免大量调用方同时重试
+func JitterBackoff(b Backoff) Backoff {
+	return func(attempt int) time.Duration {
+		d := b(attempt)
+		if d <= 1 {
+			return d
+		}
+		half := d / 2
+		return half + time.Duration(rand.Int63n(int64(d-half)))
+	}
+}
+
+type RetryPolicy struct {
+	MaxAttempts int                                               // 最多尝试次数（包括第一次），0表示不限制
+	MaxElapsed  time.Duration                                     // 从第一次尝试开始允许的总时间，下一次等待会超出时不再重试，0表示不限制
+	Backoff     Backoff                                           // 等待策略，nil 表示 ExponentialBackoff(100ms, 10s)
+	RetryIf     func(err error) bool                              // 判断错误是否需要重试，nil 表示所有错误都重试
+	OnAttempt   func(attempt int, err error)                      // 每次尝试结束后调用，成功时 err 为 nil
+	OnRetry     func(attempt int, err error, delay time.Duration) // 决定重试、开始等待之前调用
+	Clock       Clock                                             // nil 表示 SystemClock
+}
+
+// RetryError 在重试次数或时间用尽、或 ctx 结束时返回，Err 为最后一次的错误
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("retry: gave up after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// Retry 按照 policy 执行 fn 直到成功，fn 的 panic 会像 Try 一样转换为错误并参与重试
+// MaxAttempts 与 MaxElapsed 都为0时最多尝试3次
+// RetryIf 判断为不需要重试的错误原样返回；重试用尽时返回 *RetryError；等待期间 ctx 结束时返回 ctx 的错误
+func Retry(ctx context.Context, fn func(ctx context.Context) error, policy RetryPolicy) error {
+	_, err := RetryDo(ctx, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	}, policy)
+	return err
+}
+
+// RetryDo 与 Retry 相同，成功时返回 fn 的结果
+func RetryDo[T any](ctx context.Context, fn func(ctx context.Context) (T, error), policy RetryPolicy) (T, error) {
+	clock := policy.Clock
+	if clock == nil {
+		clock = SystemClock
+	}
+	backoff := policy.Backoff
+	if backoff == nil {
+		backoff = ExponentialBackoff(100*time.Millisecond, 10*time.Second)
+	}
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts <= 0 && policy.MaxElapsed <= 0 {
+		maxAttempts = 3
+	}
+
+	start := clock.Now()
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return *new(T), err
+		}
+		var v T
+		var err error
+		if perr := Try(func() { v, err = fn(ctx) }); perr != nil {
+			err = perr
+		}
+		if policy.OnAttempt != nil {
+			policy.OnAttempt(attempt, err)
+		}
+		if err == nil {
+			return v, nil
+		}
+		if policy.RetryIf != nil && !policy.RetryIf(err) {
+			return *new(T), err
+		}
+		if maxAttempts > 0 && attempt >= maxAttempts {
+			return *new(T), &RetryError{Attempts: attempt, Err: err}
+		}
+		delay := backoff(attempt)
+		if policy.MaxElapsed > 0 && clock.Now().Add(delay).Sub(start) > policy.MaxElapsed {
+			return *new(T), &RetryError{Attempts: attempt, Err: err}
+		}
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, err, delay)
+		}
+		if delay > 0 {
+			select {
+			case <-clock.After(delay):
+			case <-ctx.Done():
+				return *new(T), ctx.Err()
+			}
+		}
+	}
+}
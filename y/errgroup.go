@@ -1,6 +1,7 @@
 package y
 
 import (
+	"context"
 	"fmt"
 	"runtime"
 	"sync"
@@ -11,22 +12,47 @@ import (
 type WaitGroup struct {
 	errgroup.Group
 	sync.RWMutex
+	cancel context.CancelFunc
+}
+
+// WaitGroupWithContext 创建一个绑定 ctx 的 WaitGroup
+// 任意任务返回错误（或 panic）时会取消返回的 ctx，Wait 结束后同样会取消
+func WaitGroupWithContext(ctx context.Context) (*WaitGroup, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &WaitGroup{cancel: cancel}, ctx
 }
 
 func (g *WaitGroup) Go(f func() error) {
-	g.Group.Go(func() (err error) {
-		defer func() {
-			if r := recover(); r != nil {
-				// 打印调用栈
-				buf := make([]byte, 1024)
-				n := runtime.Stack(buf, false)
-				err = fmt.Errorf("panic: %v\n%s", r, buf[:n])
-			}
-		}()
-		return f()
+	g.Group.Go(func() error {
+		err := recoverGo(f)
+		if err != nil && g.cancel != nil {
+			g.cancel()
+		}
+		return err
 	})
 }
 
+// recoverGo 执行 f，并将 f 的 panic 转换为带调用栈的错误，WaitGroup 与 TaskGroup 共用
+func recoverGo(f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			// 打印调用栈
+			buf := make([]byte, 1024)
+			n := runtime.Stack(buf, false)
+			err = fmt.Errorf("panic: %v\n%s", r, buf[:n])
+		}
+	}()
+	return f()
+}
+
+func (g *WaitGroup) Wait() error {
+	err := g.Group.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	return err
+}
+
 func (g *WaitGroup) goWithPanic(f func() error) {
 	g.Group.Go(f)
 }
@@ -0,0 +1,172 @@
+package y
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// TaskGroup 与 WaitGroup 类似，但会按提交顺序收集每个任务的返回值，并记录所有失败的任务
+// 零值可以直接使用，任务失败不会影响其他任务；通过 TaskGroupWithContext 创建时，任意任务失败都会取消 ctx，尚未开始的任务不再执行
+//
+//	g, _ := y.TaskGroupWithContext[int](ctx)
+//	g.SetLimit(4)
+//	for _, id := range ids {
+//		id := id
+//		g.Go(func(ctx context.Context) (int, error) { return load(ctx, id) })
+//	}
+//	results, err := g.Wait()
+type TaskGroup[R any] struct {
+	group    errgroup.Group
+	ctx      context.Context
+	cancel   context.CancelFunc
+	timeout  time.Duration
+	progress func(done, total int)
+
+	mu      sync.Mutex
+	results []R
+	errs    []*TaskError
+	done    int
+
+	progressMu sync.Mutex
+}
+
+// TaskError 记录失败任务的下标与错误，任务 panic 时 Err 与 WaitGroup 相同，为带调用栈的错误
+type TaskError struct {
+	Index int
+	Err   error
+}
+
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("task %d: %v", e.Index, e.Err)
+}
+
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+// TaskErrors 是 TaskGroup.Wait 返回的多个错误，按任务下标排列
+type TaskErrors []*TaskError
+
+func (e TaskErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d tasks failed:\n%s", len(e), strings.Join(msgs, "\n"))
+}
+
+func (e TaskErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// TaskGroupWithContext 创建一个绑定 ctx 的 TaskGroup，第一个任务失败时取消返回的 ctx，Wait 结束后同样会取消
+func TaskGroupWithContext[R any](ctx context.Context) (*TaskGroup[R], context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &TaskGroup[R]{ctx: ctx, cancel: cancel}, ctx
+}
+
+// SetLimit 限制同时运行的任务数，需要在 Go 之前调用，n < 0 表示不限制
+func (g *TaskGroup[R]) SetLimit(n int) {
+	g.group.SetLimit(n)
+}
+
+// SetTimeout 为每个任务单独设置超时，任务收到的 ctx 会在 d 之后取消，需要在 Go 之前调用
+func (g *TaskGroup[R]) SetTimeout(d time.Duration) {
+	g.timeout = d
+}
+
+// OnProgress 设置进度回调，每个任务结束后以 (已完成数, 已提交数) 调用，回调是串行的
+// 需要在 Go 之前调用，回调中不能再调用 Go 与 Wait
+func (g *TaskGroup[R]) OnProgress(fn func(done, total int)) {
+	g.progress = fn
+}
+
+// Go 提交任务并返回任务下标，达到并发上限时会阻塞
+// 开始执行前 ctx 已取消的任务不会执行，以 ctx 的错误记为失败
+func (g *TaskGroup[R]) Go(fn func(ctx context.Context) (R, error)) int {
+	g.mu.Lock()
+	index := len(g.results)
+	g.results = append(g.results, *new(R))
+	g.mu.Unlock()
+
+	g.group.Go(func() error {
+		r, taskErr := g.run(index, fn)
+		if g.progress != nil {
+			// 保证回调按完成数递增的顺序调用
+			g.progressMu.Lock()
+			defer g.progressMu.Unlock()
+		}
+		g.mu.Lock()
+		if taskErr != nil {
+			g.errs = append(g.errs, taskErr)
+		} else {
+			g.results[index] = r
+		}
+		g.done++
+		done, total := g.done, len(g.results)
+		g.mu.Unlock()
+
+		if taskErr != nil && g.cancel != nil {
+			g.cancel()
+		}
+		if g.progress != nil {
+			g.progress(done, total)
+		}
+		return nil
+	})
+	return index
+}
+
+func (g *TaskGroup[R]) run(index int, fn func(ctx context.Context) (R, error)) (R, *TaskError) {
+	var r R
+	ctx := g.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return r, &TaskError{Index: index, Err: err}
+	}
+	if g.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.timeout)
+		defer cancel()
+	}
+	err := recoverGo(func() (err error) {
+		r, err = fn(ctx)
+		return err
+	})
+	if err != nil {
+		return r, &TaskError{Index: index, Err: err}
+	}
+	return r, nil
+}
+
+// Wait 等待所有任务结束，按提交顺序返回结果，失败任务的位置为零值
+// 有任务失败时返回 TaskErrors
+func (g *TaskGroup[R]) Wait() ([]R, error) {
+	g.group.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.errs) == 0 {
+		return g.results, nil
+	}
+	errs := make(TaskErrors, len(g.errs))
+	copy(errs, g.errs)
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Index < errs[j].Index
+	})
+	return g.results, errs
+}
@@ -0,0 +1,187 @@
+package y
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Future 表示一个异步计算的结果，结果只会被设置一次，可以被多次、并发地等待
+type Future[T any] struct {
+	done  chan struct{}
+	once  sync.Once
+	value T
+	err   error
+}
+
+func newFuture[T any]() *Future[T] {
+	return &Future[T]{done: make(chan struct{})}
+}
+
+// complete 设置结果，只有第一次调用生效
+func (f *Future[T]) complete(v T, err error) {
+	f.once.Do(func() {
+		f.value = v
+		f.err = err
+		close(f.done)
+	})
+}
+
+// Done 返回一个在结果就绪时关闭的 channel
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Await 等待结果，ctx 先结束时返回 ctx 的错误，此时计算本身不会被中断
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		return *new(T), ctx.Err()
+	}
+}
+
+// Async 在新的协程中执行 fn 并返回 Future，fn 的 panic 会像 Try 一样转换为带调用栈的错误
+//
+//	user := y.Async(func() (User, error) { return loadUser(id) })
+//	orders := y.Async(func() ([]Order, error) { return loadOrders(id) })
+//	u, err := user.Await(ctx)
+func Async[T any](fn func() (T, error)) *Future[T] {
+	f := newFuture[T]()
+	go func() {
+		var v T
+		var err error
+		if perr := Try(func() { v, err = fn() }); perr != nil {
+			err = perr
+		}
+		f.complete(v, err)
+	}()
+	return f
+}
+
+// Then 在 f 成功后以其结果调用 fn，f 失败时直接传递错误，不会调用 fn
+func Then[T any, R any](f *Future[T], fn func(T) (R, error)) *Future[R] {
+	next := newFuture[R]()
+	go func() {
+		<-f.done
+		if f.err != nil {
+			next.complete(*new(R), f.err)
+			return
+		}
+		var v R
+		var err error
+		if perr := Try(func() { v, err = fn(f.value) }); perr != nil {
+			err = perr
+		}
+		next.complete(v, err)
+	}()
+	return next
+}
+
+// Catch 在 f 失败时以错误调用 fn，可以返回替代值或新的错误；f 成功时原样传递结果
+func (f *Future[T]) Catch(fn func(error) (T, error)) *Future[T] {
+	next := newFuture[T]()
+	go func() {
+		<-f.done
+		if f.err == nil {
+			next.complete(f.value, nil)
+			return
+		}
+		var v T
+		var err error
+		if perr := Try(func() { v, err = fn(f.err) }); perr != nil {
+			err = perr
+		}
+		next.complete(v, err)
+	}()
+	return next
+}
+
+// Timeout 返回一个新的 Future，f 在 d 内没有完成时以 context.DeadlineExceeded 失败
+func (f *Future[T]) Timeout(d time.Duration) *Future[T] {
+	next := newFuture[T]()
+	go func() {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-f.done:
+			next.complete(f.value, f.err)
+		case <-timer.C:
+			next.complete(*new(T), context.DeadlineExceeded)
+		}
+	}()
+	return next
+}
+
+// All 等待全部 Future 成功，结果与参数顺序一致；任意一个失败时立即以该错误失败
+func All[T any](fs ...*Future[T]) *Future[[]T] {
+	next := newFuture[[]T]()
+	go func() {
+		result := make([]T, len(fs))
+		remaining := len(fs)
+		cases := makeDoneCases(fs)
+		for remaining > 0 {
+			i, _, _ := reflect.Select(cases)
+			cases[i].Chan = reflect.Value{}
+			remaining--
+			if fs[i].err != nil {
+				next.complete(nil, fs[i].err)
+				return
+			}
+			result[i] = fs[i].value
+		}
+		next.complete(result, nil)
+	}()
+	return next
+}
+
+// Any 返回第一个成功的结果，全部失败时返回由 errors.Join 合并的所有错误
+func Any[T any](fs ...*Future[T]) *Future[T] {
+	next := newFuture[T]()
+	go func() {
+		errs := make([]error, len(fs))
+		remaining := len(fs)
+		cases := makeDoneCases(fs)
+		for remaining > 0 {
+			i, _, _ := reflect.Select(cases)
+			cases[i].Chan = reflect.Value{}
+			remaining--
+			if fs[i].err == nil {
+				next.complete(fs[i].value, nil)
+				return
+			}
+			errs[i] = fs[i].err
+		}
+		if len(fs) == 0 {
+			errs = append(errs, errors.New("y.Any: no futures"))
+		}
+		next.complete(*new(T), errors.Join(errs...))
+	}()
+	return next
+}
+
+// Race 返回第一个完成的结果，无论成功还是失败
+func Race[T any](fs ...*Future[T]) *Future[T] {
+	next := newFuture[T]()
+	if len(fs) == 0 {
+		next.complete(*new(T), errors.New("y.Race: no futures"))
+		return next
+	}
+	go func() {
+		i, _, _ := reflect.Select(makeDoneCases(fs))
+		next.complete(fs[i].value, fs[i].err)
+	}()
+	return next
+}
+
+// makeDoneCases 为每个 Future 的 done 生成 select 分支，已处理的分支将 Chan 置空即可忽略
+func makeDoneCases[T any](fs []*Future[T]) []reflect.SelectCase {
+	cases := make([]reflect.SelectCase, len(fs))
+	for i, f := range fs {
+		cases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(f.done)}
+	}
+	return cases
+}
@@ -219,17 +219,6 @@ package y
 // 	return result
 // }
 
-// func For[T any](arr []T, fn func(T, int) bool) {
-// 	var source []T
-// 	source = arr
-// 	for i, v := range source {
-// 		c := fn(v, i)
-// 		if !c {
-// 			return
-// 		}
-// 	}
-// }
-
 // func Distinct[T any, A array[T]](arr A, fn func(T, int) any) {
 // 	var mp = make(map[any]bool)
 // 	var result []T
@@ -290,48 +279,6 @@ package y
 // // 	return nil
 // // }
 
-// func Group[T any](arr []T, fn func(T, int) any) [][]T {
-// 	var result = stlx.NewMultiMap[any, T]()
-// 	for i, v := range arr {
-// 		k := fn(v, i)
-// 		if k == nil {
-// 			continue
-// 		}
-// 		result.Set(k, v)
-// 	}
-// 	return result.Vals()
-// }
-
-// func Chunk[T any](arr []T, size int) [][]T {
-// 	var result = make([][]T, 0, len(arr)/size+1)
-// 	for i := 0; i < len(arr); i += size {
-// 		end := i + size
-// 		if end > len(arr) {
-// 			end = len(arr)
-// 		}
-// 		result = append(result, arr[i:end])
-// 	}
-// 	return result
-// }
-
-// func GroupMap[K comparable, V any](arr []V, fn func(V, int) K) map[K][]V {
-// 	var result = make(map[K][]V)
-// 	for i, v := range arr {
-// 		k := fn(v, i)
-// 		result[k] = append(result[k], v)
-// 	}
-// 	return result
-// }
-
-// func ToMap[K comparable, V any](arr []V, fn func(V, int) K) map[K]V {
-// 	var result = make(map[K]V)
-// 	for i, v := range arr {
-// 		k := fn(v, i)
-// 		result[k] = v
-// 	}
-// 	return result
-// }
-
 // func timSort[T any](arr []T, less func(T, T) bool) []T {
 // 	if len(arr) <= 1 {
 // 		return arr
@@ -1,23 +1,61 @@
 package y
 
 type delFunc[T any] interface {
-	func(item T, index int) bool | func(item T) bool | func(item *T, index int) bool | func(item *T) bool | option
+	func(item T, index int) bool | func(item T) bool | func(item *T, index int) bool | func(item *T) bool | option | *where
 }
 
-func Del[T any, K delFunc[T]](arr []T, fn K, opts ...any) []T {
-	// 如果第一个是一个方法
-	switch fn := any(fn).(type) {
-	case func(T) bool:
-		return filter0(arr, fn)
-	case func(T, int) bool:
-		return filter1(arr, fn)
-	case func(*T) bool:
-		return filter2(arr, fn)
-	case func(*T, int) bool:
-		return filter3(arr, fn)
-	default:
-		// 处理opts
-		opts = append([]any{fn}, opts...)
+// Del 删除满足条件的元素，返回新切片与删除的个数，不会修改原切片
+// 条件可以是函数，也可以是与 Filter 相同的 Is/Not 值列表：
+//
+//	y.Del(arr, func(v int) bool { return v > 3 }) // 删除大于3的元素
+//	y.Del(arr, y.Is, 1, 2)                       // 删除1和2
+//	y.Del(arr, y.Not, 1, 2)                      // 删除除1和2以外的元素
+//	y.Del(arr, y.NotNil, y.NotEmpty)             // 删除nil和空值
+//	y.Del(arr, y.Where("age<18"))                // 删除满足条件的元素
+func Del[T any, K delFunc[T]](arr []T, fn K, opts ...any) ([]T, int) {
+	checkOptions("Del", opts, filterOptions)
+	return del(arr, make([]T, 0, len(arr)), makeDelFunc[T](fn, opts))
+}
+
+// DelInPlace 与 Del 相同，但直接在 *arr 上删除，不会重新分配内存，返回删除的个数
+func DelInPlace[T any, K delFunc[T]](arr *[]T, fn K, opts ...any) int {
+	checkOptions("DelInPlace", opts, filterOptions)
+	src := *arr
+	result, removed := del(src, src[:0], makeDelFunc[T](fn, opts))
+	// 清空尾部，避免持有已删除元素的引用
+	var zero T
+	for i := len(result); i < len(src); i++ {
+		src[i] = zero
+	}
+	*arr = result
+	return removed
+}
+
+// makeDelFunc 与 Filter 共用条件的解析，返回 true 表示删除
+// 函数条件的结果直接作为是否删除；NotNil、NotEmpty 删除 nil 与空值，Is/Not 值列表与 Where 删除匹配的元素
+func makeDelFunc[T any](fn any, opts []any) func(*T, int) bool {
+	return makePred[T](fn, opts, (*filterOption).matchDel)
+}
+
+func (filterOption *filterOption) matchDel(v any) bool {
+	if filterOption.ignoreNil && isNil(v) {
+		return true
+	}
+	if filterOption.ignoreEmpty && isZero(v) {
+		return true
+	}
+	return filterOption.hasList() && filterOption.matchList(v)
+}
+
+// del 将 src 中未删除的元素追加到 dst，dst 可以与 src 共用底层数组
+func del[T any](src []T, dst []T, remove func(*T, int) bool) ([]T, int) {
+	removed := 0
+	for i := range src {
+		if remove(&src[i], i) {
+			removed++
+			continue
+		}
+		dst = append(dst, src[i])
 	}
-	return nil
+	return dst, removed
 }
@@ -1,16 +1,26 @@
 package y
 
+import (
+	"context"
+	"reflect"
+)
+
 type distinctFunc[T any] interface {
 	func(T, int) any | func(*T, int) any | func(T) any | func(*T) any
 }
 
 func Distinct[T any](arr []T, fn ...any) []T {
+	checkOptions("Distinct", fn, distinctOptions)
+	var key any
+	if len(fn) > 0 {
+		key = unwrapKey(fn[0])
+	}
 	var mp = make(map[any]bool)
 	var result []T
 	for i, v := range arr {
 		var k any
-		if len(fn) > 0 {
-			k = doDistinct(fn[0], &arr[i], i)
+		if key != nil {
+			k = doDistinct(key, &arr[i], i)
 		} else {
 			k = v
 		}
@@ -37,3 +47,171 @@ func doDistinct[T any](fn any, v *T, i int) any {
 		return *v
 	}
 }
+
+type distinctEFunc[T any] interface {
+	func(T, int) (any, error) | func(*T, int) (any, error) | func(T) (any, error) | func(*T) (any, error)
+}
+
+// DistinctE 是 Distinct 的错误版本，取键函数可以返回错误
+// 支持 UseAsync、WithLimit、WithTimeout 与 UseAllErrors，错误语义同 FlexE
+func DistinctE[T any, K distinctEFunc[T]](arr []T, fn K, opts ...Option) ([]T, error) {
+	checkOptions("DistinctE", opts, flexCtxOptions)
+	var key func(int) (any, error)
+	switch fn := any(fn).(type) {
+	case func(T, int) (any, error):
+		key = func(i int) (any, error) { return fn(arr[i], i) }
+	case func(*T, int) (any, error):
+		key = func(i int) (any, error) { return fn(&arr[i], i) }
+	case func(T) (any, error):
+		key = func(i int) (any, error) { return fn(arr[i]) }
+	case func(*T) (any, error):
+		key = func(i int) (any, error) { return fn(&arr[i]) }
+	}
+	var flexOption flexOption
+	makeFlexOptions(&flexOption, opts...)
+	keys, err := flexCtx(context.Background(), arr, func(_ context.Context, _ T, i int) (any, error) {
+		return key(i)
+	}, &flexOption)
+	if err != nil {
+		return nil, err
+	}
+	var mp = make(map[any]bool)
+	var result []T
+	for i, v := range arr {
+		if mp[keys[i]] {
+			continue
+		}
+		result = append(result, v)
+		mp[keys[i]] = true
+	}
+	return result, nil
+}
+
+// Hasher 计算键的哈希值，哈希相同的键再由 Equal 判断是否相等
+type Hasher[K any] func(K) uint64
+
+// Equal 判断两个键是否相等
+type Equal[K any] func(a, b K) bool
+
+// Merge 合并重复元素，kept 为已保留的元素，dup 为后出现的重复元素
+type Merge[T any] func(kept, dup T) T
+
+func (Hasher[K]) optionKind() option {
+	return withHasher
+}
+
+func (Equal[K]) optionKind() option {
+	return withEqual
+}
+
+func (Merge[T]) optionKind() option {
+	return withMerge
+}
+
+// WithHasher 为 DistinctBy 指定哈希函数
+func WithHasher[K any](fn func(K) uint64) Hasher[K] {
+	return fn
+}
+
+// WithEqual 为 DistinctBy 指定相等判断，未指定时使用 reflect.DeepEqual
+// 只指定 WithEqual 时无法分桶，每个键都要与已保留的键逐个比较，数据量大时应同时指定与之一致的 WithHasher
+func WithEqual[K any](fn func(a, b K) bool) Equal[K] {
+	return fn
+}
+
+// WithMerge 为 DistinctBy 指定重复元素的合并方式，结果位于第一次出现的位置
+func WithMerge[T any](fn func(kept, dup T) T) Merge[T] {
+	return fn
+}
+
+// DistinctBy 按 key 去重，与 Distinct 不同，键可以是切片、映射或包含它们的结构体：
+// 可比较的键直接放入 map，不可比较的键使用结构化哈希 Hash 分桶，再用 reflect.DeepEqual 判断相等；
+// 也可以通过 WithHasher、WithEqual 自定义，指定了任意一个时所有的键都按分桶比较
+// 重复元素默认保留第一个，KeepLast 保留最后一个（结果按最后出现的位置排列），WithMerge 合并重复元素
+func DistinctBy[T any, K any](arr []T, key func(T) K, opts ...Option) []T {
+	checkOptions("DistinctBy", opts, distinctByOptions)
+	var hasher Hasher[K]
+	var equal Equal[K]
+	var merge Merge[T]
+	var keepLast bool
+	for _, opt := range opts {
+		switch opt := opt.(type) {
+		case Hasher[K]:
+			hasher = opt
+		case Equal[K]:
+			equal = opt
+		case Merge[T]:
+			merge = opt
+		case option:
+			if opt == KeepLast {
+				keepLast = true
+			}
+		}
+	}
+	// 自定义的相等判断可能认为哈希不同的键相等，没有对应的哈希函数时只能放在同一个桶中
+	singleBucket := hasher == nil && equal != nil
+	if equal == nil {
+		equal = func(a, b K) bool {
+			return reflect.DeepEqual(a, b)
+		}
+	}
+
+	source := arr
+	if keepLast && merge == nil {
+		// 倒序保留第一个，再翻转回来
+		source = make([]T, len(arr))
+		for i := range arr {
+			source[len(arr)-1-i] = arr[i]
+		}
+	}
+
+	var result []T
+	var keys []K
+	hashable := make(map[any]int)
+	buckets := make(map[uint64][]int)
+	for _, v := range source {
+		k := key(v)
+		var bucket uint64
+		var useBucket bool
+		index := -1
+		if hasher != nil {
+			bucket, useBucket = hasher(k), true
+		} else if singleBucket {
+			useBucket = true
+		} else if kv := reflect.ValueOf(any(k)); kv.IsValid() && !kv.Comparable() {
+			bucket, useBucket = Hash(k), true
+		}
+		if useBucket {
+			for _, i := range buckets[bucket] {
+				if equal(keys[i], k) {
+					index = i
+					break
+				}
+			}
+		} else if i, ok := hashable[any(k)]; ok {
+			index = i
+		}
+
+		if index != -1 {
+			if merge != nil {
+				result[index] = merge(result[index], v)
+			}
+			continue
+		}
+		index = len(result)
+		result = append(result, v)
+		keys = append(keys, k)
+		if useBucket {
+			buckets[bucket] = append(buckets[bucket], index)
+		} else {
+			hashable[any(k)] = index
+		}
+	}
+
+	if keepLast && merge == nil {
+		for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+			result[i], result[j] = result[j], result[i]
+		}
+	}
+	return result
+}
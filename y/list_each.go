@@ -0,0 +1,137 @@
+package y
+
+import (
+	"context"
+	"runtime"
+)
+
+type eachFunc[T any] interface {
+	func(T) | func(T, int) | func(T) bool | func(T, int) bool |
+		func(*T) | func(*T, int) | func(*T) bool | func(*T, int) bool
+}
+
+// ForEach 依次遍历元素，回调返回 false 时提前结束
+// 支持与 Find 相同的选项：NotNil、NotEmpty、WithOffset(n)、UseReverse
+func ForEach[T any, K eachFunc[T]](arr []T, fn K, opts ...Option) {
+	checkOptions("ForEach", opts, findOptions)
+	var each func(*T, int) bool
+	switch fn := any(fn).(type) {
+	case func(T):
+		each = func(v *T, _ int) bool { fn(*v); return true }
+	case func(T, int):
+		each = func(v *T, i int) bool { fn(*v, i); return true }
+	case func(T) bool:
+		each = func(v *T, _ int) bool { return fn(*v) }
+	case func(T, int) bool:
+		each = func(v *T, i int) bool { return fn(*v, i) }
+	case func(*T):
+		each = func(v *T, _ int) bool { fn(v); return true }
+	case func(*T, int):
+		each = func(v *T, i int) bool { fn(v, i); return true }
+	case func(*T) bool:
+		each = func(v *T, _ int) bool { return fn(v) }
+	case func(*T, int) bool:
+		each = fn
+	}
+	findEach(arr, func(*T) bool { return true }, opts, func(i int) bool {
+		return each(&arr[i], i)
+	})
+}
+
+// EachResult 是 ParallelEach 输出的单个结果
+type EachResult[R any] struct {
+	Index int
+	Value R
+	Err   error
+}
+
+// ParallelEach 并发处理元素，并按输入顺序通过 channel 逐个输出结果，处理完成或 ctx 取消后关闭 channel
+// 并发数默认为 runtime.GOMAXPROCS(0)，可以通过 WithLimit(n) 指定，WithTimeout(d) 的效果与 ctx 超时相同；
+// 领先于当前输出位置的结果最多缓存 2*limit 个，消费变慢时会暂停启动新的任务，因此不会像 Flex 一样持有全部结果
+// 回调的错误与 panic 会放在对应结果的 Err 中，不会中断其他元素的处理
+// 调用方必须读完 channel 或者取消 ctx，中途停止读取又不取消会使内部的协程一直阻塞
+// ctx 取消（或超时）时，channel 关闭前的最后一个结果的 Err 为 ctx 的错误、Index 为第一个没有输出的下标，
+// 可以据此区分被取消与正常结束；已经处理完但还没有被读取的结果可能被这个结果替代
+func ParallelEach[T any, R any](ctx context.Context, arr []T, fn func(context.Context, T, int) (R, error), opts ...Option) <-chan EachResult[R] {
+	checkOptions("ParallelEach", opts, eachOptions)
+	var flexOption flexOption
+	makeFlexOptions(&flexOption, opts...)
+	limit := flexOption.limit
+	if limit <= 0 {
+		limit = runtime.GOMAXPROCS(0)
+	}
+	cancel := func() {}
+	if flexOption.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, flexOption.timeout)
+	}
+
+	// 留一个缓冲，取消时总能放入最后的错误结果，不会因为调用方不再读取而阻塞
+	out := make(chan EachResult[R], 1)
+	// 按输入顺序排队的结果槽，加上 out 的一个缓冲即重排缓冲区的大小
+	pending := make(chan chan EachResult[R], limit*2-1)
+
+	// 分发
+	go func() {
+		defer close(pending)
+		var wg WaitGroup
+		wg.SetLimit(limit)
+		defer wg.Wait()
+		for i := range arr {
+			slot := make(chan EachResult[R], 1)
+			select {
+			case pending <- slot:
+			case <-ctx.Done():
+				return
+			}
+			i := i
+			wg.goWithPanic(func() error {
+				result := EachResult[R]{Index: i}
+				if err := ctx.Err(); err != nil {
+					result.Err = err
+				} else if err := Try(func() { result.Value, result.Err = fn(ctx, arr[i], i) }); err != nil {
+					result.Err = err
+				}
+				slot <- result
+				return nil
+			})
+		}
+	}()
+
+	// 按顺序输出
+	go func() {
+		defer cancel()
+		defer close(out)
+		next := 0
+	loop:
+		for slot := range pending {
+			var result EachResult[R]
+			select {
+			case result = <-slot:
+			case <-ctx.Done():
+				break loop
+			}
+			select {
+			case out <- result:
+				next++
+			case <-ctx.Done():
+				break loop
+			}
+		}
+		if next == len(arr) {
+			return
+		}
+		final := EachResult[R]{Index: next, Err: ctx.Err()}
+		select {
+		case out <- final:
+		default:
+			// 缓冲中还有未读取的结果，用错误结果替代它；只有这里会发送，取出后一定有空位
+			select {
+			case r := <-out:
+				final.Index = r.Index
+			default:
+			}
+			out <- final
+		}
+	}()
+	return out
+}
@@ -1,14 +1,17 @@
 package y
 
 import (
+	"context"
+	"fmt"
 	"reflect"
 )
 
 type filterFunc[T any] interface {
-	func(T) bool | func(T, int) bool | func(*T) bool | func(*T, int) bool | option
+	func(T) bool | func(T, int) bool | func(*T) bool | func(*T, int) bool | option | *where
 }
 
 func Filter[T any, K filterFunc[T]](arr []T, fn K, opts ...any) []T {
+	checkOptions("Filter", opts, filterOptions)
 	// 如果第一个是一个方法
 	switch fn := any(fn).(type) {
 	case func(T) bool:
@@ -23,12 +26,27 @@ func Filter[T any, K filterFunc[T]](arr []T, fn K, opts ...any) []T {
 		// 处理opts
 		opts = append([]any{fn}, opts...)
 	}
-	filterOption := struct {
-		include     []any
-		exclude     []any
-		ignoreNil   bool
-		ignoreEmpty bool
-	}{
+	filterOption := makeFilterOption(opts)
+	var result = make([]T, 0, len(arr))
+	for _, v := range arr {
+		if filterOption.match(v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+type filterOption struct {
+	include     []any
+	exclude     []any
+	ignoreNil   bool
+	ignoreEmpty bool
+	wheres      []*where
+}
+
+// makeFilterOption 解析 Is/Not 值列表、Where 条件以及 NotNil、NotEmpty
+func makeFilterOption(opts []any) *filterOption {
+	filterOption := &filterOption{
 		include:     make([]any, 0),
 		exclude:     make([]any, 0),
 		ignoreNil:   false,
@@ -47,6 +65,11 @@ func Filter[T any, K filterFunc[T]](arr []T, fn K, opts ...any) []T {
 			case NotEmpty:
 				filterOption.ignoreEmpty = true
 			}
+		} else if w, ok := opt.(*where); ok {
+			filterOption.wheres = append(filterOption.wheres, w)
+		} else if _, ok := opt.(Option); ok {
+			// WithLimit 等不支持的选项直接忽略，不能当作比较值
+			continue
 		} else {
 			switch last {
 			case Is:
@@ -58,37 +81,74 @@ func Filter[T any, K filterFunc[T]](arr []T, fn K, opts ...any) []T {
 			}
 		}
 	}
-	var result = make([]T, 0, len(arr))
-	var shouldContinue bool
-	for _, v := range arr {
-		if filterOption.ignoreNil && isNil(v) {
-			continue
+	return filterOption
+}
+
+// match 判断元素是否满足过滤条件
+func (filterOption *filterOption) match(v any) bool {
+	if filterOption.ignoreNil && isNil(v) {
+		return false
+	}
+	if filterOption.ignoreEmpty && isZero(v) {
+		return false
+	}
+	return filterOption.matchList(v)
+}
+
+// hasList 是否指定了 Is/Not 值列表或 Where 条件
+func (filterOption *filterOption) hasList() bool {
+	return len(filterOption.include) > 0 || len(filterOption.exclude) > 0 || len(filterOption.wheres) > 0
+}
+
+// matchList 判断元素是否满足 Is/Not 值列表以及 Where 条件
+func (filterOption *filterOption) matchList(v any) bool {
+	for _, w := range filterOption.wheres {
+		if !w.match(v) {
+			return false
 		}
-		if filterOption.ignoreEmpty && isZero(v) {
-			continue
+	}
+	for _, exclude := range filterOption.exclude {
+		if exclude == v {
+			return false
 		}
-		shouldContinue = true
-		for _, exclude := range filterOption.exclude {
-			if exclude == any(v) {
-				shouldContinue = false
-				break
+	}
+	if len(filterOption.include) > 0 {
+		for _, include := range filterOption.include {
+			if include == v {
+				return true
 			}
 		}
-		if !shouldContinue {
-			continue
-		}
-		if len(filterOption.include) > 0 {
-			for _, include := range filterOption.include {
-				if include == any(v) {
-					result = append(result, v)
-					break
-				}
-			}
-		} else {
-			result = append(result, v)
-		}
+		return false
+	}
+	return true
+}
+
+// makeFilterPred 将 Filter 支持的各种条件统一为 func(*T, int) bool，返回 true 表示保留
+func makeFilterPred[T any](fn any, opts []any) func(*T, int) bool {
+	return makePred[T](fn, opts, (*filterOption).match)
+}
+
+// makePred 将函数形式的条件统一为 func(*T, int) bool，
+// 其他形式（Is/Not 值列表、Where、NotNil、NotEmpty）解析为 filterOption 后交给 match 判断
+func makePred[T any](fn any, opts []any, match func(*filterOption, any) bool) func(*T, int) bool {
+	switch fn := fn.(type) {
+	case func(T) bool:
+		return func(v *T, _ int) bool { return fn(*v) }
+	case func(T, int) bool:
+		return func(v *T, i int) bool { return fn(*v, i) }
+	case func(*T) bool:
+		return func(v *T, _ int) bool { return fn(v) }
+	case func(*T, int) bool:
+		return fn
+	case option, *where:
+		opts = append([]any{fn}, opts...)
+	default:
+		panic(fmt.Sprintf("y: unsupported condition type %T for []%s", fn, reflect.TypeOf((*T)(nil)).Elem()))
+	}
+	filterOption := makeFilterOption(opts)
+	return func(v *T, _ int) bool {
+		return match(filterOption, *v)
 	}
-	return result
 }
 
 func filter0[T any](arr []T, fn func(T) bool) []T {
@@ -132,7 +192,16 @@ func filter3[T any](arr []T, fn func(*T, int) bool) []T {
 }
 
 func isNil(v any) bool {
-	return v == nil
+	if v == nil {
+		return true
+	}
+	val := reflect.ValueOf(v)
+	// 检查是否为指针、切片、映射、通道、函数或接口
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func, reflect.Interface:
+		return val.IsNil()
+	}
+	return false
 }
 
 func isZero[T any](v T) bool {
@@ -151,3 +220,39 @@ func isZero[T any](v T) bool {
 		return reflect.ValueOf(v).IsZero()
 	}
 }
+
+type filterEFunc[T any] interface {
+	func(T) (bool, error) | func(T, int) (bool, error) | func(*T) (bool, error) | func(*T, int) (bool, error)
+}
+
+// FilterE 是 Filter 的错误版本，过滤函数可以返回错误
+// 支持 UseAsync、WithLimit、WithTimeout 与 UseAllErrors，错误语义同 FlexE
+func FilterE[T any, K filterEFunc[T]](arr []T, fn K, opts ...Option) ([]T, error) {
+	checkOptions("FilterE", opts, flexCtxOptions)
+	var pred func(int) (bool, error)
+	switch fn := any(fn).(type) {
+	case func(T) (bool, error):
+		pred = func(i int) (bool, error) { return fn(arr[i]) }
+	case func(T, int) (bool, error):
+		pred = func(i int) (bool, error) { return fn(arr[i], i) }
+	case func(*T) (bool, error):
+		pred = func(i int) (bool, error) { return fn(&arr[i]) }
+	case func(*T, int) (bool, error):
+		pred = func(i int) (bool, error) { return fn(&arr[i], i) }
+	}
+	var flexOption flexOption
+	makeFlexOptions(&flexOption, opts...)
+	keep, err := flexCtx(context.Background(), arr, func(_ context.Context, _ T, i int) (bool, error) {
+		return pred(i)
+	}, &flexOption)
+	if err != nil {
+		return nil, err
+	}
+	var result = make([]T, 0, len(arr))
+	for i := range arr {
+		if keep[i] {
+			result = append(result, arr[i])
+		}
+	}
+	return result, nil
+}
@@ -4,53 +4,163 @@ type findFunc[T any] interface {
 	func(T) bool | func(T, int) bool | func(*T) bool | func(*T, int) bool | any
 }
 
-func Find[T any, K findFunc[T]](arr []T, fn K, opts ...any) (T, bool) {
-	if len(opts) == 0 {
-		return *new(T), false
-	}
-	index := Pos(arr, fn)
+// 查找函数的条件可以是回调，也可以是一个值（按 == 比较），支持以下选项：
+//
+//	NotNil / NotEmpty  跳过 nil / 空值元素
+//	WithOffset(n)      从下标 n 开始查找，负数表示从末尾倒数
+//	UseReverse         从后向前查找
+
+// Find 返回第一个满足条件的元素
+func Find[T any, K findFunc[T]](arr []T, fn K, opts ...Option) (T, bool) {
+	checkOptions("Find", opts, findOptions)
+	return find(arr, fn, opts)
+}
+
+// FindLast 返回最后一个满足条件的元素
+func FindLast[T any, K findFunc[T]](arr []T, fn K, opts ...Option) (T, bool) {
+	checkOptions("FindLast", opts, findOptions)
+	return find(arr, fn, append(opts, UseReverse))
+}
+
+// Pos 返回第一个满足条件的元素下标，没有则返回 -1
+func Pos[T any, K findFunc[T]](arr []T, fn K, opts ...Option) int {
+	checkOptions("Pos", opts, findOptions)
+	return pos(arr, fn, opts)
+}
+
+// PosLast 返回最后一个满足条件的元素下标，没有则返回 -1
+func PosLast[T any, K findFunc[T]](arr []T, fn K, opts ...Option) int {
+	checkOptions("PosLast", opts, findOptions)
+	return pos(arr, fn, append(opts, UseReverse))
+}
+
+// PosAll 返回所有满足条件的元素下标，顺序与查找方向一致
+func PosAll[T any, K findFunc[T]](arr []T, fn K, opts ...Option) []int {
+	checkOptions("PosAll", opts, findOptions)
+	result := make([]int, 0)
+	findEach(arr, fn, opts, func(i int) bool {
+		result = append(result, i)
+		return true
+	})
+	return result
+}
+
+// Count 返回满足条件的元素个数
+func Count[T any, K findFunc[T]](arr []T, fn K, opts ...Option) int {
+	checkOptions("Count", opts, findOptions)
+	count := 0
+	findEach(arr, fn, opts, func(i int) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+func Has[T any, K findFunc[T]](arr []T, target K, opts ...Option) bool {
+	checkOptions("Has", opts, findOptions)
+	return pos(arr, target, opts) != -1
+}
+
+func find[T any](arr []T, fn any, opts []Option) (T, bool) {
+	index := pos(arr, fn, opts)
 	if index == -1 {
 		return *new(T), false
 	}
 	return arr[index], true
 }
 
-func Pos[T any, K findFunc[T]](arr []T, fn K) int {
-	switch fn := any(fn).(type) {
-	case func(T, int) bool:
-		for i, _ := range arr {
-			if fn(arr[i], i) {
-				return i
+func pos[T any](arr []T, fn any, opts []Option) int {
+	index := -1
+	findEach(arr, fn, opts, func(i int) bool {
+		index = i
+		return false
+	})
+	return index
+}
+
+type findOption struct {
+	offset      int
+	reverse     bool
+	ignoreNil   bool
+	ignoreEmpty bool
+}
+
+func makeFindOption(n int, opts []Option) findOption {
+	findOption := findOption{offset: -1}
+	for _, opt := range opts {
+		switch opt := opt.(type) {
+		case offsetOption:
+			findOption.offset = int(opt)
+			if findOption.offset < 0 {
+				findOption.offset += n
+				if findOption.offset < 0 {
+					findOption.offset = 0
+				}
 			}
-		}
-	case func(T) bool:
-		for i, _ := range arr {
-			if fn(arr[i]) {
-				return i
+		case option:
+			switch opt {
+			case UseReverse:
+				findOption.reverse = true
+			case NotNil:
+				findOption.ignoreNil = true
+			case NotEmpty:
+				findOption.ignoreEmpty = true
 			}
 		}
-	case func(*T) bool:
-		for i, _ := range arr {
-			if fn(&arr[i]) {
-				return i
-			}
+	}
+	return findOption
+}
+
+// findEach 按选项指定的方向依次回调满足条件的下标，yield 返回 false 时停止
+func findEach[T any](arr []T, fn any, opts []Option, yield func(int) bool) {
+	findOption := makeFindOption(len(arr), opts)
+	match := makeFindPred[T](fn)
+	check := func(i int) bool {
+		if findOption.ignoreNil && isNil(arr[i]) {
+			return true
 		}
-	case func(*T, int) bool:
-		for i, _ := range arr {
-			if fn(&arr[i], i) {
-				return i
-			}
+		if findOption.ignoreEmpty && isZero(arr[i]) {
+			return true
 		}
-	default:
-		for i, _ := range arr {
-			if any(arr[i]) == fn {
-				return i
+		if !match(&arr[i], i) {
+			return true
+		}
+		return yield(i)
+	}
+	if findOption.reverse {
+		start := len(arr) - 1
+		if findOption.offset >= 0 && findOption.offset < start {
+			start = findOption.offset
+		}
+		for i := start; i >= 0; i-- {
+			if !check(i) {
+				return
 			}
 		}
+		return
+	}
+	start := 0
+	if findOption.offset > 0 {
+		start = findOption.offset
+	}
+	for i := start; i < len(arr); i++ {
+		if !check(i) {
+			return
+		}
 	}
-	return -1
 }
 
-func Has[T any, K findFunc[T]](arr []T, target K) bool {
-	return Pos(arr, target) != -1
+func makeFindPred[T any](fn any) func(*T, int) bool {
+	switch fn := fn.(type) {
+	case func(T, int) bool:
+		return func(v *T, i int) bool { return fn(*v, i) }
+	case func(T) bool:
+		return func(v *T, _ int) bool { return fn(*v) }
+	case func(*T) bool:
+		return func(v *T, _ int) bool { return fn(v) }
+	case func(*T, int) bool:
+		return fn
+	default:
+		return func(v *T, _ int) bool { return any(*v) == fn }
+	}
 }
@@ -0,0 +1,104 @@
+package y
+
+type keyFunc[T any, K comparable] interface {
+	func(T) K | func(T, int) K | func(*T) K | func(*T, int) K
+}
+
+// Group 按键分组，分组顺序为键第一次出现的顺序，键为 nil 的元素会被忽略
+// 键的类型无法从回调推断，需要显式指定：
+//
+//	y.Group[string](users, func(u User) string { return u.Dept })
+func Group[K comparable, T any, F keyFunc[T, K]](arr []T, fn F) [][]T {
+	return GroupMap[K](arr, fn).Vals()
+}
+
+// GroupMap 按键分组，返回按首次出现顺序排列的有序映射
+func GroupMap[K comparable, T any, F keyFunc[T, K]](arr []T, fn F) *Map[K, []T] {
+	key := makeKeyFunc[T, K](fn)
+	result := NewMap[K, []T]()
+	for i := range arr {
+		k := key(&arr[i], i)
+		if any(k) == nil {
+			continue
+		}
+		v, _ := result.get(k)
+		result.set(k, append(v, arr[i]))
+	}
+	return result
+}
+
+// ToMap 按键建立索引，键重复时后出现的元素覆盖之前的值，但保留键首次出现的位置
+func ToMap[K comparable, T any, F keyFunc[T, K]](arr []T, fn F) *Map[K, T] {
+	key := makeKeyFunc[T, K](fn)
+	result := NewMap[K, T]()
+	for i := range arr {
+		k := key(&arr[i], i)
+		if any(k) == nil {
+			continue
+		}
+		result.set(k, arr[i])
+	}
+	return result
+}
+
+// CountBy 按键计数，返回按首次出现顺序排列的有序映射
+func CountBy[K comparable, T any, F keyFunc[T, K]](arr []T, fn F) *Map[K, int] {
+	key := makeKeyFunc[T, K](fn)
+	result := NewMap[K, int]()
+	for i := range arr {
+		k := key(&arr[i], i)
+		if any(k) == nil {
+			continue
+		}
+		n, _ := result.get(k)
+		result.set(k, n+1)
+	}
+	return result
+}
+
+// Partition 按条件将切片拆分为满足与不满足两部分，条件的写法与 Filter 一致
+func Partition[T any, K filterFunc[T]](arr []T, fn K, opts ...any) ([]T, []T) {
+	checkOptions("Partition", opts, filterOptions)
+	pred := makeFilterPred[T](fn, opts)
+	var matched = make([]T, 0)
+	var rest = make([]T, 0)
+	for i := range arr {
+		if pred(&arr[i], i) {
+			matched = append(matched, arr[i])
+		} else {
+			rest = append(rest, arr[i])
+		}
+	}
+	return matched, rest
+}
+
+// Chunk 按固定大小切分，最后一块可能不足 size，各块与原切片共用底层数组
+func Chunk[T any](arr []T, size int) [][]T {
+	if size <= 0 {
+		panic("chunk size must be positive")
+	}
+	var result = make([][]T, 0, len(arr)/size+1)
+	for i := 0; i < len(arr); i += size {
+		end := i + size
+		if end > len(arr) {
+			end = len(arr)
+		}
+		result = append(result, arr[i:end:end])
+	}
+	return result
+}
+
+func makeKeyFunc[T any, K comparable](fn any) func(*T, int) K {
+	switch fn := fn.(type) {
+	case func(T) K:
+		return func(v *T, _ int) K { return fn(*v) }
+	case func(T, int) K:
+		return func(v *T, i int) K { return fn(*v, i) }
+	case func(*T) K:
+		return func(v *T, _ int) K { return fn(v) }
+	case func(*T, int) K:
+		return fn
+	}
+	// will never reach here
+	return nil
+}
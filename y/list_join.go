@@ -0,0 +1,115 @@
+package y
+
+// 基于哈希的连接：按键把 left 与 right 中的元素配对，支持一对多
+// 元素较少的一侧用于建立哈希表，另一侧用于探测；结果始终按 left 的顺序排列，同一 left 元素的多个匹配按 right 的顺序排列
+// 可选的一侧以指针表示，未匹配时为 nil，指针指向传入切片中的元素
+
+// InnerJoin 内连接，只返回两侧都能匹配的元素对
+func InnerJoin[L, R any, K comparable](left []L, right []R, lkey func(L) K, rkey func(R) K) []Tuple2[L, R] {
+	matches, _ := joinIndex(left, right, lkey, rkey)
+	result := make([]Tuple2[L, R], 0, len(left))
+	for li, rs := range matches {
+		for _, ri := range rs {
+			result = append(result, T(left[li], right[ri]))
+		}
+	}
+	return result
+}
+
+// LeftJoin 左连接，left 中未匹配的元素与 nil 配对
+func LeftJoin[L, R any, K comparable](left []L, right []R, lkey func(L) K, rkey func(R) K) []Tuple2[L, *R] {
+	matches, _ := joinIndex(left, right, lkey, rkey)
+	result := make([]Tuple2[L, *R], 0, len(left))
+	for li, rs := range matches {
+		if len(rs) == 0 {
+			result = append(result, T[L, *R](left[li], nil))
+			continue
+		}
+		for _, ri := range rs {
+			result = append(result, T(left[li], &right[ri]))
+		}
+	}
+	return result
+}
+
+// RightJoin 右连接，right 中未匹配的元素与 nil 配对，结果按 right 的顺序排列
+func RightJoin[L, R any, K comparable](left []L, right []R, lkey func(L) K, rkey func(R) K) []Tuple2[*L, R] {
+	pairs := LeftJoin(right, left, rkey, lkey)
+	result := make([]Tuple2[*L, R], len(pairs))
+	for i, p := range pairs {
+		result[i] = T(p.b, p.a)
+	}
+	return result
+}
+
+// OuterJoin 全外连接，先按 left 的顺序输出匹配及未匹配的 left 元素，再按顺序输出未匹配的 right 元素
+func OuterJoin[L, R any, K comparable](left []L, right []R, lkey func(L) K, rkey func(R) K) []Tuple2[*L, *R] {
+	matches, matched := joinIndex(left, right, lkey, rkey)
+	result := make([]Tuple2[*L, *R], 0, len(left))
+	for li, rs := range matches {
+		if len(rs) == 0 {
+			result = append(result, T[*L, *R](&left[li], nil))
+			continue
+		}
+		for _, ri := range rs {
+			result = append(result, T(&left[li], &right[ri]))
+		}
+	}
+	for ri := range right {
+		if !matched[ri] {
+			result = append(result, T[*L, *R](nil, &right[ri]))
+		}
+	}
+	return result
+}
+
+// JoinAs 将连接结果合并为 D，依次把左右两侧通过 Cast 写入同一个 D，右侧的同名字段会覆盖左侧
+// nil 的一侧会被跳过
+func JoinAs[D any, A, B any](pairs []Tuple2[A, B]) ([]D, error) {
+	result := make([]D, len(pairs))
+	for i, p := range pairs {
+		for _, side := range []any{p.a, p.b} {
+			if isNil(side) {
+				continue
+			}
+			if err := Cast(&result[i], side); err != nil {
+				return nil, &IndexError{Index: i, Err: err}
+			}
+		}
+	}
+	return result, nil
+}
+
+// joinIndex 返回每个 left 元素匹配到的 right 下标，以及每个 right 元素是否被匹配
+func joinIndex[L, R any, K comparable](left []L, right []R, lkey func(L) K, rkey func(R) K) ([][]int, []bool) {
+	matches := make([][]int, len(left))
+	matched := make([]bool, len(right))
+	if len(left) <= len(right) {
+		// 对 left 建哈希表，按顺序探测 right，保证每个 left 的匹配按 right 的顺序追加
+		index := make(map[K][]int, len(left))
+		for li := range left {
+			k := lkey(left[li])
+			index[k] = append(index[k], li)
+		}
+		for ri := range right {
+			for _, li := range index[rkey(right[ri])] {
+				matches[li] = append(matches[li], ri)
+				matched[ri] = true
+			}
+		}
+	} else {
+		index := make(map[K][]int, len(right))
+		for ri := range right {
+			k := rkey(right[ri])
+			index[k] = append(index[k], ri)
+		}
+		for li := range left {
+			rs := index[lkey(left[li])]
+			matches[li] = rs
+			for _, ri := range rs {
+				matched[ri] = true
+			}
+		}
+	}
+	return matches, matched
+}
@@ -1,9 +1,11 @@
 package y
 
 import (
+	"context"
+	"errors"
 	"log"
-	"reflect"
 	"runtime"
+	"time"
 )
 
 type flexOption struct {
@@ -13,15 +15,43 @@ type flexOption struct {
 	async       bool
 	distinct    bool
 	isFlatFlex  bool
+	allErrors   bool
+	limit       int
+	timeout     time.Duration
+	pool        *WorkerPool
 }
 
-func Flex[T any, R any](arr []T, fn func(T, int) R, opts ...option) []R {
+func Flex[T any, R any](arr []T, fn func(T, int) R, opts ...Option) []R {
+	checkOptions("Flex", opts, flexOptions)
 	var flexOption flexOption
-	makeFlexOption(&flexOption, opts...)
+	makeFlexOptions(&flexOption, opts...)
 	result := make([]R, len(arr))
-	if flexOption.async {
+	if flexOption.pool != nil {
+		// 在共享的协程池中执行
+		futures := make([]*Future[R], len(arr))
+		for i := range arr {
+			i := i
+			futures[i] = Submit(context.Background(), flexOption.pool, func(context.Context) (R, error) {
+				return fn(arr[i], i), nil
+			})
+		}
+		for i, f := range futures {
+			r, err := f.Await(context.Background())
+			if err != nil {
+				if flexOption.isPanic {
+					panic(err)
+				}
+				log.Println(err)
+			}
+			result[i] = r
+		}
+	} else if flexOption.async {
+		limit := flexOption.limit
+		if limit <= 0 {
+			limit = runtime.GOMAXPROCS(0)
+		}
 		var wg = WaitGroup{}
-		wg.SetLimit(runtime.GOMAXPROCS(0))
+		wg.SetLimit(limit)
 		for i, _ := range arr {
 			i := i
 			wg.goWithPanic(func() error {
@@ -58,10 +88,11 @@ func Flex[T any, R any](arr []T, fn func(T, int) R, opts ...option) []R {
 	return applyFlexOption(&flexOption, result)
 }
 
-func FlatFlex[T any, R any](arr []T, fn func(T, int) []R, opts ...option) []R {
+func FlatFlex[T any, R any](arr []T, fn func(T, int) []R, opts ...Option) []R {
+	checkOptions("FlatFlex", opts, flexOptions)
 	opts = append(opts, isFlatFlex)
 	var flexOption flexOption
-	makeFlexOption(&flexOption, opts...)
+	makeFlexOptions(&flexOption, opts...)
 	var _result = Flex(arr, fn, opts...)
 	var result = make([]R, 0)
 	for _, v := range _result {
@@ -70,6 +101,146 @@ func FlatFlex[T any, R any](arr []T, fn func(T, int) []R, opts ...option) []R {
 	return applyFlexOption(&flexOption, result)
 }
 
+// FlexCtx 是 Flex 的 context 版本，映射函数接收 ctx 并可以返回错误
+// 使用 UseAsync 时并发执行，并发数默认为 runtime.GOMAXPROCS(0)，可以通过 WithLimit(n) 指定
+// ctx 取消或达到 WithTimeout(d) 指定的时间后不再启动新的任务，第一个错误或 panic 会作为 error 返回
+func FlexCtx[T any, R any](ctx context.Context, arr []T, fn func(context.Context, T, int) (R, error), opts ...Option) ([]R, error) {
+	checkOptions("FlexCtx", opts, flexCtxOptions)
+	var flexOption flexOption
+	makeFlexOptions(&flexOption, opts...)
+	result, err := flexCtx(ctx, arr, fn, &flexOption)
+	if err != nil {
+		return nil, err
+	}
+	return applyFlexOption(&flexOption, result), nil
+}
+
+// FlatFlexCtx 是 FlatFlex 的 context 版本，语义同 FlexCtx
+func FlatFlexCtx[T any, R any](ctx context.Context, arr []T, fn func(context.Context, T, int) ([]R, error), opts ...Option) ([]R, error) {
+	checkOptions("FlatFlexCtx", opts, flexCtxOptions)
+	var flexOption flexOption
+	makeFlexOptions(&flexOption, opts...)
+	_result, err := flexCtx(ctx, arr, fn, &flexOption)
+	if err != nil {
+		return nil, err
+	}
+	var result = make([]R, 0)
+	for _, v := range _result {
+		result = append(result, v...)
+	}
+	return applyFlexOption(&flexOption, result), nil
+}
+
+// FlexE 是 Flex 的错误版本，映射函数可以返回错误
+// 默认在第一个错误处停止，使用 UseAllErrors 时执行全部元素并合并所有错误
+// 返回的错误为 *IndexError（或由 errors.Join 合并的多个 *IndexError），可以获取出错的下标
+func FlexE[T any, R any](arr []T, fn func(T, int) (R, error), opts ...Option) ([]R, error) {
+	checkOptions("FlexE", opts, flexCtxOptions)
+	var flexOption flexOption
+	makeFlexOptions(&flexOption, opts...)
+	result, err := flexCtx(context.Background(), arr, func(_ context.Context, v T, i int) (R, error) {
+		return fn(v, i)
+	}, &flexOption)
+	if err != nil {
+		return nil, err
+	}
+	return applyFlexOption(&flexOption, result), nil
+}
+
+func flexCtx[T any, R any](ctx context.Context, arr []T, fn func(context.Context, T, int) (R, error), flexOption *flexOption) ([]R, error) {
+	if flexOption.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, flexOption.timeout)
+		defer cancel()
+	}
+	result := make([]R, len(arr))
+	// 收集所有错误时按下标记录
+	var errs []error
+	if flexOption.allErrors {
+		errs = make([]error, len(arr))
+	}
+	call := func(ctx context.Context, i int) error {
+		var r R
+		var err error
+		if perr := Try(func() { r, err = fn(ctx, arr[i], i) }); perr != nil {
+			err = perr
+		}
+		if err != nil {
+			err = &IndexError{Index: i, Err: err}
+			if errs != nil {
+				errs[i] = err
+				return nil
+			}
+			return err
+		}
+		result[i] = r
+		return nil
+	}
+
+	if !flexOption.async {
+		// 同步
+		for i := range arr {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if err := call(ctx, i); err != nil {
+				return nil, err
+			}
+		}
+		if err := errors.Join(errs...); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	limit := flexOption.limit
+	if limit <= 0 {
+		limit = runtime.GOMAXPROCS(0)
+	}
+	wg, gctx := WaitGroupWithContext(ctx)
+	wg.SetLimit(limit)
+	for i := range arr {
+		// 已取消则不再启动新的任务
+		if gctx.Err() != nil {
+			break
+		}
+		i := i
+		wg.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			return call(gctx, i)
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+	// 外部 ctx 在任何任务启动前被取消
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// makeFlexOptions 解析 flexOption，opts 可以是 []Option，也可以是混有值参数的 []any
+func makeFlexOptions[O any](flexOption *flexOption, opts ...O) {
+	for _, opt := range opts {
+		switch opt := any(opt).(type) {
+		case option:
+			makeFlexOption(flexOption, opt)
+		case limitOption:
+			flexOption.limit = int(opt)
+		case timeoutOption:
+			flexOption.timeout = time.Duration(opt)
+		case poolOption:
+			flexOption.pool = opt.pool
+		}
+	}
+}
+
 func makeFlexOption(flexOption *flexOption, opts ...option) {
 	for _, opt := range opts {
 		switch opt {
@@ -85,6 +256,8 @@ func makeFlexOption(flexOption *flexOption, opts ...option) {
 			flexOption.isPanic = true
 		case isFlatFlex:
 			flexOption.isFlatFlex = true
+		case UseAllErrors:
+			flexOption.allErrors = true
 		}
 	}
 }
@@ -93,30 +266,7 @@ func applyFlexOption[R any](flexOption *flexOption, result []R) []R {
 	// 如果需要过滤
 	if flexOption.ignoreNil || flexOption.ignoreEmpty {
 		result = Filter(result, func(v R) bool {
-			if flexOption.ignoreNil {
-				rv := any(v)
-				if rv == nil {
-					return false
-				}
-				val := reflect.ValueOf(rv)
-				// 检查是否为指针、切片、映射、通道、函数或接口
-				switch val.Kind() {
-				case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func, reflect.Interface:
-					if val.IsNil() {
-						return false
-					}
-				}
-			}
-			if flexOption.ignoreEmpty {
-				rv := any(v)
-				if rv == nil {
-					return false
-				}
-				if isZero(rv) {
-					return false
-				}
-			}
-			return true
+			return flexOption.keep(v)
 		})
 	}
 	if flexOption.distinct {
@@ -126,3 +276,19 @@ func applyFlexOption[R any](flexOption *flexOption, result []R) []R {
 	}
 	return result
 }
+
+// keep 判断元素在 NotNil、NotEmpty 下是否保留
+func (flexOption *flexOption) keep(rv any) bool {
+	if flexOption.ignoreNil && isNil(rv) {
+		return false
+	}
+	if flexOption.ignoreEmpty {
+		if rv == nil {
+			return false
+		}
+		if isZero(rv) {
+			return false
+		}
+	}
+	return true
+}
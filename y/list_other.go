@@ -9,3 +9,108 @@ func Reduce[T any, R any](arr []T, fn func(R, T) R, initial R) R {
 	}
 	return result
 }
+
+// ReduceE 是 Reduce 的错误版本，在第一个错误或 panic 处停止并返回 *IndexError
+func ReduceE[T any, R any](arr []T, fn func(R, T) (R, error), initial R) (R, error) {
+	result := initial
+	for i, v := range arr {
+		var err error
+		if perr := Try(func() { result, err = fn(result, v) }); perr != nil {
+			err = perr
+		}
+		if err != nil {
+			return *new(R), &IndexError{Index: i, Err: err}
+		}
+	}
+	return result, nil
+}
+
+// number 可以进行算术运算的数值类型
+type number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Scan 与 Reduce 相同，但返回每一步的累加结果，长度与 arr 相同
+func Scan[T any, R any](arr []T, fn func(R, T) R, initial R) []R {
+	result := make([]R, 0, len(arr))
+	Reduce(arr, func(acc R, v T) R {
+		acc = fn(acc, v)
+		result = append(result, acc)
+		return acc
+	}, initial)
+	return result
+}
+
+// Window 返回长度为 size、每次向后移动 step 的滑动窗口，不足 size 的尾部会被丢弃
+// 各窗口与原切片共用底层数组
+func Window[T any](arr []T, size, step int) [][]T {
+	if size <= 0 || step <= 0 {
+		panic("window size and step must be positive")
+	}
+	result := make([][]T, 0)
+	for i := 0; i+size <= len(arr); i += step {
+		result = append(result, arr[i:i+size:i+size])
+	}
+	return result
+}
+
+// Pairwise 返回相邻元素组成的 Tuple2，例如 [1,2,3] 返回 (1,2)、(2,3)
+func Pairwise[T any](arr []T) []Tuple2[T, T] {
+	if len(arr) < 2 {
+		return []Tuple2[T, T]{}
+	}
+	return Zip(arr[:len(arr)-1], arr[1:])
+}
+
+// Sum 求和
+func Sum[N number](arr []N) N {
+	return Reduce(arr, func(acc N, v N) N {
+		return acc + v
+	}, 0)
+}
+
+// Avg 求平均值，空切片返回 0
+func Avg[N number](arr []N) float64 {
+	if len(arr) == 0 {
+		return 0
+	}
+	return Reduce(arr, func(acc float64, v N) float64 {
+		return acc + float64(v)
+	}, 0) / float64(len(arr))
+}
+
+// Min 返回最小值，空切片返回 false
+func Min[T ordered](arr []T) (T, bool) {
+	return MinBy(arr, func(v T) T { return v })
+}
+
+// Max 返回最大值，空切片返回 false
+func Max[T ordered](arr []T) (T, bool) {
+	return MaxBy(arr, func(v T) T { return v })
+}
+
+// MinBy 返回 key 最小的元素，多个元素相等时返回第一个，空切片返回 false
+func MinBy[T any, K ordered](arr []T, key func(T) K) (T, bool) {
+	return extremeBy(arr, key, func(a, b K) bool { return a < b })
+}
+
+// MaxBy 返回 key 最大的元素，多个元素相等时返回第一个，空切片返回 false
+func MaxBy[T any, K ordered](arr []T, key func(T) K) (T, bool) {
+	return extremeBy(arr, key, func(a, b K) bool { return a > b })
+}
+
+func extremeBy[T any, K ordered](arr []T, key func(T) K, better func(a, b K) bool) (T, bool) {
+	if len(arr) == 0 {
+		var zero T
+		return zero, false
+	}
+	best, bestKey := arr[0], key(arr[0])
+	for _, v := range arr[1:] {
+		if k := key(v); better(k, bestKey) {
+			best, bestKey = v, k
+		}
+	}
+	return best, true
+}
@@ -0,0 +1,147 @@
+package y
+
+// TopK 返回按 less 排序最大的 k 个元素，从大到小排列，相等的元素按原顺序排列
+// 使用大小为 k 的堆，复杂度 O(n log k)，不会修改原切片
+func TopK[T any](arr []T, k int, less func(T, T) bool) []T {
+	return selectK(arr, k, func(a, b T) bool {
+		return less(b, a)
+	})
+}
+
+// BottomK 返回按 less 排序最小的 k 个元素，从小到大排列，相等的元素按原顺序排列
+func BottomK[T any](arr []T, k int, less func(T, T) bool) []T {
+	return selectK(arr, k, less)
+}
+
+type heapItem[T any] struct {
+	value T
+	index int
+}
+
+// selectK 返回按 before 排在最前面的 k 个元素
+// 堆顶为当前结果中最靠后的元素，新元素只有严格排在堆顶之前才会替换堆顶
+func selectK[T any](arr []T, k int, before func(T, T) bool) []T {
+	if k > len(arr) {
+		k = len(arr)
+	}
+	if k <= 0 {
+		return []T{}
+	}
+	// after(a, b) 表示 a 排在 b 之后，下标大的视为靠后以保持稳定
+	after := func(a, b heapItem[T]) bool {
+		if before(b.value, a.value) {
+			return true
+		}
+		if before(a.value, b.value) {
+			return false
+		}
+		return a.index > b.index
+	}
+	h := make([]heapItem[T], 0, k)
+	for i := range arr {
+		item := heapItem[T]{value: arr[i], index: i}
+		if len(h) < k {
+			h = append(h, item)
+			heapUp(h, len(h)-1, after)
+			continue
+		}
+		if after(h[0], item) {
+			h[0] = item
+			heapDown(h, 0, after)
+		}
+	}
+	// 依次弹出堆顶，从后往前填充
+	result := make([]T, len(h))
+	for n := len(h); n > 0; n-- {
+		result[n-1] = h[0].value
+		h[0] = h[n-1]
+		h = h[:n-1]
+		heapDown(h, 0, after)
+	}
+	return result
+}
+
+func heapUp[T any](h []T, i int, higher func(a, b T) bool) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !higher(h[i], h[parent]) {
+			break
+		}
+		h[i], h[parent] = h[parent], h[i]
+		i = parent
+	}
+}
+
+func heapDown[T any](h []T, i int, higher func(a, b T) bool) {
+	n := len(h)
+	for {
+		top := i
+		left, right := 2*i+1, 2*i+2
+		if left < n && higher(h[left], h[top]) {
+			top = left
+		}
+		if right < n && higher(h[right], h[top]) {
+			top = right
+		}
+		if top == i {
+			return
+		}
+		h[i], h[top] = h[top], h[i]
+		i = top
+	}
+}
+
+// Nth 返回按 less 排序后下标为 n 的元素（从 0 开始），使用快速选择，平均复杂度 O(n)
+// 在副本上操作，不会修改原切片；n 越界时返回 false
+func Nth[T any](arr []T, n int, less func(T, T) bool) (T, bool) {
+	if n < 0 || n >= len(arr) {
+		var zero T
+		return zero, false
+	}
+	cp := make([]T, len(arr))
+	copy(cp, arr)
+	lo, hi := 0, len(cp)-1
+	for hi-lo > 16 {
+		p := partition(cp, lo, hi, less)
+		switch {
+		case n < p:
+			hi = p - 1
+		case n > p:
+			lo = p + 1
+		default:
+			return cp[p], true
+		}
+	}
+	binaryInsertionSort(cp, lo, hi+1, lo, less)
+	return cp[n], true
+}
+
+// partition 以三数取中作为基准划分 a[lo:hi+1]，返回基准的最终位置
+func partition[T any](a []T, lo, hi int, less func(T, T) bool) int {
+	mid := int(uint(lo+hi) >> 1)
+	if less(a[mid], a[lo]) {
+		a[mid], a[lo] = a[lo], a[mid]
+	}
+	if less(a[hi], a[lo]) {
+		a[hi], a[lo] = a[lo], a[hi]
+	}
+	if less(a[hi], a[mid]) {
+		a[hi], a[mid] = a[mid], a[hi]
+	}
+	// 此时 a[lo] <= a[mid] <= a[hi]，基准放到 hi-1
+	a[mid], a[hi-1] = a[hi-1], a[mid]
+	pivot := a[hi-1]
+	i, j := lo, hi-1
+	for {
+		for i++; less(a[i], pivot); i++ {
+		}
+		for j--; less(pivot, a[j]); j-- {
+		}
+		if i >= j {
+			break
+		}
+		a[i], a[j] = a[j], a[i]
+	}
+	a[i], a[hi-1] = a[hi-1], a[i]
+	return i
+}
@@ -0,0 +1,215 @@
+package y
+
+// Seq 是惰性序列，Filter、Flex、Distinct 等中间操作只组合迭代函数，
+// 直到 Collect、Sort、SeqReduce 等终止操作时才遍历数据并生成结果
+// 由于方法不能携带类型参数，改变元素类型的操作（SeqFlex、SeqFlatFlex、SeqReduce）以函数形式提供
+// 惰性序列按顺序逐个处理元素，UseAsync 会被忽略
+type Seq[T any] struct {
+	iter func(yield func(T) bool)
+}
+
+// NewSeq 基于切片创建惰性序列，不会复制切片
+func NewSeq[T any](arr []T) Seq[T] {
+	return Seq[T]{
+		iter: func(yield func(T) bool) {
+			for i := range arr {
+				if !yield(arr[i]) {
+					return
+				}
+			}
+		},
+	}
+}
+
+// ForEach 遍历序列，fn 返回 false 时停止
+func (s Seq[T]) ForEach(fn func(T) bool) {
+	if s.iter == nil {
+		return
+	}
+	s.iter(fn)
+}
+
+// Filter 过滤序列，参数与 Filter 一致：
+// 可以是 func(T) bool、func(T, int) bool、func(*T) bool、func(*T, int) bool，
+// 或 Is/Not 值列表、Where 以及 NotNil、NotEmpty、UseDistinct，其他类型会 panic
+func (s Seq[T]) Filter(fn any, opts ...any) Seq[T] {
+	checkOptions("Seq.Filter", opts, append(filterOptions, seqOptions...))
+	pred := makeFilterPred[T](fn, opts)
+	var flexOption flexOption
+	makeFlexOptions(&flexOption, opts...)
+	next := Seq[T]{
+		iter: func(yield func(T) bool) {
+			i := 0
+			s.ForEach(func(v T) bool {
+				ok := pred(&v, i)
+				i++
+				if !ok {
+					return true
+				}
+				return yield(v)
+			})
+		},
+	}
+	return applySeqOption(&flexOption, next)
+}
+
+// Distinct 去重，参数与 Distinct 一致，下标为元素在当前阶段的位置
+func (s Seq[T]) Distinct(fn ...any) Seq[T] {
+	checkOptions("Seq.Distinct", fn, distinctOptions)
+	var key any
+	if len(fn) > 0 {
+		key = unwrapKey(fn[0])
+	}
+	return Seq[T]{
+		iter: func(yield func(T) bool) {
+			var mp = make(map[any]bool)
+			i := 0
+			s.ForEach(func(v T) bool {
+				var k any
+				if key != nil {
+					k = doDistinct(key, &v, i)
+				} else {
+					k = v
+				}
+				i++
+				if mp[k] {
+					return true
+				}
+				mp[k] = true
+				return yield(v)
+			})
+		},
+	}
+}
+
+// Take 只保留前 n 个元素，达到数量后立即停止遍历上游
+func (s Seq[T]) Take(n int) Seq[T] {
+	return Seq[T]{
+		iter: func(yield func(T) bool) {
+			if n <= 0 {
+				return
+			}
+			count := 0
+			s.ForEach(func(v T) bool {
+				count++
+				if !yield(v) {
+					return false
+				}
+				return count < n
+			})
+		},
+	}
+}
+
+// Skip 跳过前 n 个元素
+func (s Seq[T]) Skip(n int) Seq[T] {
+	return Seq[T]{
+		iter: func(yield func(T) bool) {
+			count := 0
+			s.ForEach(func(v T) bool {
+				if count < n {
+					count++
+					return true
+				}
+				return yield(v)
+			})
+		},
+	}
+}
+
+// Collect 执行序列并生成切片
+func (s Seq[T]) Collect() []T {
+	var result = make([]T, 0)
+	s.ForEach(func(v T) bool {
+		result = append(result, v)
+		return true
+	})
+	return result
+}
+
+// Count 执行序列并返回元素个数
+func (s Seq[T]) Count() int {
+	count := 0
+	s.ForEach(func(v T) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// Sort 执行序列并排序，结果切片即为排序缓冲区，不会再额外复制
+func (s Seq[T]) Sort(less func(T, T) bool) []T {
+	return timSort(s.Collect(), less)
+}
+
+// SeqFlex 惰性映射，支持 NotNil、NotEmpty、UseDistinct
+func SeqFlex[T any, R any](s Seq[T], fn func(T, int) R, opts ...Option) Seq[R] {
+	checkOptions("SeqFlex", opts, seqOptions)
+	var flexOption flexOption
+	makeFlexOptions(&flexOption, opts...)
+	next := Seq[R]{
+		iter: func(yield func(R) bool) {
+			i := 0
+			s.ForEach(func(v T) bool {
+				r := fn(v, i)
+				i++
+				return yield(r)
+			})
+		},
+	}
+	return applySeqOption(&flexOption, next)
+}
+
+// SeqFlatFlex 惰性映射并展开，支持 NotNil、NotEmpty、UseDistinct
+func SeqFlatFlex[T any, R any](s Seq[T], fn func(T, int) []R, opts ...Option) Seq[R] {
+	checkOptions("SeqFlatFlex", opts, seqOptions)
+	var flexOption flexOption
+	makeFlexOptions(&flexOption, opts...)
+	next := Seq[R]{
+		iter: func(yield func(R) bool) {
+			i := 0
+			s.ForEach(func(v T) bool {
+				rs := fn(v, i)
+				i++
+				for _, r := range rs {
+					if !yield(r) {
+						return false
+					}
+				}
+				return true
+			})
+		},
+	}
+	return applySeqOption(&flexOption, next)
+}
+
+// SeqReduce 执行序列并聚合，语义同 Reduce
+func SeqReduce[T any, R any](s Seq[T], fn func(R, T) R, initial R) R {
+	result := initial
+	s.ForEach(func(v T) bool {
+		result = fn(result, v)
+		return true
+	})
+	return result
+}
+
+// applySeqOption 将 NotNil、NotEmpty、UseDistinct 作为惰性阶段追加到序列上
+func applySeqOption[R any](flexOption *flexOption, s Seq[R]) Seq[R] {
+	if flexOption.ignoreNil || flexOption.ignoreEmpty {
+		prev := s
+		s = Seq[R]{
+			iter: func(yield func(R) bool) {
+				prev.ForEach(func(v R) bool {
+					if !flexOption.keep(v) {
+						return true
+					}
+					return yield(v)
+				})
+			},
+		}
+	}
+	if flexOption.distinct {
+		s = s.Distinct()
+	}
+	return s
+}
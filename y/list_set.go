@@ -0,0 +1,133 @@
+package y
+
+import "runtime"
+
+// 集合运算按键比较元素，键的写法与 Distinct 一致（func(T) any、func(T, int) any、func(*T) any、func(*T, int) any），
+// 也可以通过 WithKey 传入，未指定时使用元素本身；结果按元素第一次出现的顺序排列并去重
+// 使用 UseAsync 时并行计算两侧的键，适合键的计算比较耗时或数据量较大的场景
+
+// Union 返回 a 与 b 的并集
+func Union[T any](a, b []T, opts ...any) []T {
+	checkOptions("Union", opts, setOptions)
+	ka, kb := setKeys(a, b, opts)
+	seen := make(map[any]bool, len(a)+len(b))
+	result := make([]T, 0, len(a)+len(b))
+	for i, k := range ka {
+		if !seen[k] {
+			seen[k] = true
+			result = append(result, a[i])
+		}
+	}
+	for i, k := range kb {
+		if !seen[k] {
+			seen[k] = true
+			result = append(result, b[i])
+		}
+	}
+	return result
+}
+
+// Intersect 返回同时存在于 a 与 b 中的元素，取 a 中的元素
+func Intersect[T any](a, b []T, opts ...any) []T {
+	checkOptions("Intersect", opts, setOptions)
+	ka, kb := setKeys(a, b, opts)
+	inB := keySet(kb)
+	seen := make(map[any]bool)
+	result := make([]T, 0)
+	for i, k := range ka {
+		if inB[k] && !seen[k] {
+			seen[k] = true
+			result = append(result, a[i])
+		}
+	}
+	return result
+}
+
+// Except 返回存在于 a 但不存在于 b 中的元素
+func Except[T any](a, b []T, opts ...any) []T {
+	checkOptions("Except", opts, setOptions)
+	ka, kb := setKeys(a, b, opts)
+	return except(a, ka, keySet(kb))
+}
+
+// SymmetricDiff 返回只存在于其中一侧的元素，先 a 后 b
+func SymmetricDiff[T any](a, b []T, opts ...any) []T {
+	checkOptions("SymmetricDiff", opts, setOptions)
+	ka, kb := setKeys(a, b, opts)
+	result := except(a, ka, keySet(kb))
+	return append(result, except(b, kb, keySet(ka))...)
+}
+
+func except[T any](arr []T, keys []any, exclude map[any]bool) []T {
+	seen := make(map[any]bool)
+	result := make([]T, 0)
+	for i, k := range keys {
+		if !exclude[k] && !seen[k] {
+			seen[k] = true
+			result = append(result, arr[i])
+		}
+	}
+	return result
+}
+
+func keySet(keys []any) map[any]bool {
+	set := make(map[any]bool, len(keys))
+	for _, k := range keys {
+		set[k] = true
+	}
+	return set
+}
+
+// setKeys 计算两侧所有元素的键
+func setKeys[T any](a, b []T, opts []any) ([]any, []any) {
+	var fn any
+	var async bool
+	for _, opt := range opts {
+		if opt == UseAsync {
+			async = true
+			continue
+		}
+		if _, ok := opt.(option); !ok {
+			fn = unwrapKey(opt)
+		}
+	}
+	ka := make([]any, len(a))
+	kb := make([]any, len(b))
+	if !async {
+		fillKeys(a, ka, fn, 0, len(a))
+		fillKeys(b, kb, fn, 0, len(b))
+		return ka, kb
+	}
+
+	var wg WaitGroup
+	wg.SetLimit(runtime.GOMAXPROCS(0))
+	chunk := (len(a)+len(b))/runtime.GOMAXPROCS(0) + 1
+	for _, side := range []struct {
+		arr  []T
+		keys []any
+	}{{a, ka}, {b, kb}} {
+		arr, keys := side.arr, side.keys
+		for lo := 0; lo < len(arr); lo += chunk {
+			lo := lo
+			hi := lo + chunk
+			if hi > len(arr) {
+				hi = len(arr)
+			}
+			wg.Go(func() error {
+				fillKeys(arr, keys, fn, lo, hi)
+				return nil
+			})
+		}
+	}
+	// 与同步执行一致，键函数的 panic 传递给调用方
+	if err := wg.Wait(); err != nil {
+		panic(err)
+	}
+	return ka, kb
+}
+
+func fillKeys[T any](arr []T, keys []any, fn any, lo, hi int) {
+	for i := lo; i < hi; i++ {
+		keys[i] = doDistinct(fn, &arr[i], i)
+	}
+}
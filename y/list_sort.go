@@ -1,9 +1,23 @@
 package y
 
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
 type array[T any] interface {
 	[]T | *[]T
 }
 
+// ordered 可以使用 < 比较的类型
+type ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// Sort 稳定排序
+// 传入 []T 时返回排序后的副本，原切片不变；传入 *[]T 时直接在原切片上排序，并返回排序后的切片
 func Sort[T any, A array[T]](arr A, less func(T, T) bool) []T {
 	switch arr := any(arr).(type) {
 	case []T:
@@ -12,99 +26,691 @@ func Sort[T any, A array[T]](arr A, less func(T, T) bool) []T {
 		cp = timSort(cp, less)
 		return cp
 	case *[]T:
-		cp := make([]T, len(*arr))
-		copy(cp, *arr)
-		cp = timSort(cp, less)
-		return cp
+		return timSort(*arr, less)
 	}
 	// will never reach here
 	return nil
 }
 
+// SortKey 排序键，由 Asc、Desc 等函数创建，供 SortBy 使用
+type SortKey[T any] struct {
+	compare    func(a, b T) int
+	isNull     func(T) bool
+	desc       bool
+	nullsFirst bool
+}
+
+// Asc 按 key 升序
+func Asc[T any, K ordered](key func(T) K) SortKey[T] {
+	return SortKey[T]{
+		compare: func(a, b T) int {
+			return compareOrdered(key(a), key(b))
+		},
+	}
+}
+
+// Desc 按 key 降序
+func Desc[T any, K ordered](key func(T) K) SortKey[T] {
+	k := Asc(key)
+	k.desc = true
+	return k
+}
+
+// AscPtr 按指针指向的值升序，nil 视为空值，默认排在最后
+func AscPtr[T any, K ordered](key func(T) *K) SortKey[T] {
+	return SortKey[T]{
+		compare: func(a, b T) int {
+			return compareOrdered(*key(a), *key(b))
+		},
+		isNull: func(v T) bool {
+			return key(v) == nil
+		},
+	}
+}
+
+// DescPtr 按指针指向的值降序，nil 视为空值，默认排在最后
+func DescPtr[T any, K ordered](key func(T) *K) SortKey[T] {
+	k := AscPtr(key)
+	k.desc = true
+	return k
+}
+
+// AscFold 按字符串升序，忽略大小写
+func AscFold[T any](key func(T) string) SortKey[T] {
+	return SortKey[T]{
+		compare: func(a, b T) int {
+			return compareFold(key(a), key(b))
+		},
+	}
+}
+
+// DescFold 按字符串降序，忽略大小写
+func DescFold[T any](key func(T) string) SortKey[T] {
+	k := AscFold(key)
+	k.desc = true
+	return k
+}
+
+// NullIf 额外指定哪些元素视为空值，例如空字符串，AscPtr、DescPtr 的 nil 仍然视为空值
+func (k SortKey[T]) NullIf(fn func(T) bool) SortKey[T] {
+	if isNull := k.isNull; isNull != nil {
+		k.isNull = func(v T) bool {
+			return isNull(v) || fn(v)
+		}
+	} else {
+		k.isNull = fn
+	}
+	return k
+}
+
+// NullsFirst 空值排在最前面，不受升降序影响
+func (k SortKey[T]) NullsFirst() SortKey[T] {
+	k.nullsFirst = true
+	return k
+}
+
+// NullsLast 空值排在最后面，不受升降序影响
+func (k SortKey[T]) NullsLast() SortKey[T] {
+	k.nullsFirst = false
+	return k
+}
+
+func (k SortKey[T]) cmp(a, b T) int {
+	if k.isNull != nil {
+		an, bn := k.isNull(a), k.isNull(b)
+		switch {
+		case an && bn:
+			return 0
+		case an:
+			if k.nullsFirst {
+				return -1
+			}
+			return 1
+		case bn:
+			if k.nullsFirst {
+				return 1
+			}
+			return -1
+		}
+	}
+	c := k.compare(a, b)
+	if k.desc {
+		return -c
+	}
+	return c
+}
+
+// SortBy 按多个键稳定排序，前面的键优先
+//
+//	y.SortBy(users, y.Asc(func(u User) string { return u.Dept }), y.Desc(func(u User) int { return u.Age }))
+func SortBy[T any, A array[T]](arr A, keys ...SortKey[T]) []T {
+	return Sort(arr, func(a, b T) bool {
+		for _, k := range keys {
+			if c := k.cmp(a, b); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// compareFold 逐个字符按小写比较，结果与比较 strings.ToLower 之后的字符串一致，但不需要分配内存
+func compareFold(a, b string) int {
+	for a != "" && b != "" {
+		var ra, rb rune
+		if a[0] < utf8.RuneSelf {
+			ra, a = rune(a[0]), a[1:]
+			if 'A' <= ra && ra <= 'Z' {
+				ra += 'a' - 'A'
+			}
+		} else {
+			r, size := utf8.DecodeRuneInString(a)
+			ra, a = unicode.ToLower(r), a[size:]
+		}
+		if b[0] < utf8.RuneSelf {
+			rb, b = rune(b[0]), b[1:]
+			if 'A' <= rb && rb <= 'Z' {
+				rb += 'a' - 'A'
+			}
+		} else {
+			r, size := utf8.DecodeRuneInString(b)
+			rb, b = unicode.ToLower(r), b[size:]
+		}
+		if ra != rb {
+			return compareOrdered(ra, rb)
+		}
+	}
+	return compareOrdered(len(a), len(b))
+}
+
+func compareOrdered[K ordered](a, b K) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+const (
+	// 小于该长度的切片直接使用二分插入排序
+	timSortMinMerge = 32
+	// 进入 galloping 模式的初始阈值
+	timSortMinGallop = 7
+)
+
+// timSort 稳定排序：识别自然有序的段（降序段会被翻转），不足 minRun 的段用二分插入排序补齐，
+// 再按 timsort 的栈不变式合并；合并前用 galloping 裁掉已经就位的部分，合并中连续命中一侧时切换到 galloping 模式，
+// 整个排序只使用一个按需扩容的合并缓冲区
 func timSort[T any](arr []T, less func(T, T) bool) []T {
-	if len(arr) <= 1 {
+	n := len(arr)
+	if n < 2 {
 		return arr
 	}
 
-	const minRun = 32
-	const maxRun = 64
-
-	// 计算最小运行长度
-	runLength := minRun
-	if len(arr) < minRun {
-		runLength = len(arr)
+	if n < timSortMinMerge {
+		runLen := countRunAndMakeAscending(arr, 0, n, less)
+		binaryInsertionSort(arr, 0, n, runLen, less)
+		return arr
 	}
 
-	// 插入排序辅助函数
-	insertionSort := func(arr []T, left, right int) {
-		for i := left + 1; i <= right; i++ {
-			key := arr[i]
-			j := i - 1
-			for j >= left && less(key, arr[j]) {
-				arr[j+1] = arr[j]
-				j--
+	ts := &timSorter[T]{
+		a:         arr,
+		less:      less,
+		minGallop: timSortMinGallop,
+	}
+	minRun := timSortMinRun(n)
+	for lo := 0; lo < n; {
+		runLen := countRunAndMakeAscending(arr, lo, n, less)
+		// 自然段太短时用二分插入排序扩展到 minRun
+		if runLen < minRun {
+			force := minRun
+			if n-lo < force {
+				force = n - lo
 			}
-			arr[j+1] = key
+			binaryInsertionSort(arr, lo, lo+force, lo+runLen, less)
+			runLen = force
 		}
+		ts.pushRun(lo, runLen)
+		ts.mergeCollapse()
+		lo += runLen
 	}
+	ts.mergeForceCollapse()
+	return arr
+}
+
+type timRun struct {
+	base int
+	len  int
+}
+
+type timSorter[T any] struct {
+	a         []T
+	less      func(T, T) bool
+	minGallop int
+	buf       []T // 合并缓冲区，在整个排序过程中复用
+	runs      []timRun
+}
+
+// timSortMinRun 计算最小段长度，使 n/minRun 接近且不超过 2 的幂
+func timSortMinRun(n int) int {
+	r := 0
+	for n >= timSortMinMerge {
+		r |= n & 1
+		n >>= 1
+	}
+	return n + r
+}
 
-	// 合并两个有序子数组
-	merge := func(arr []T, l, m, r int) {
-		left := make([]T, m-l+1)
-		right := make([]T, r-m)
-		copy(left, arr[l:m+1])
-		copy(right, arr[m+1:r+1])
+// countRunAndMakeAscending 返回从 lo 开始的自然有序段长度，严格降序的段会被原地翻转
+func countRunAndMakeAscending[T any](a []T, lo, hi int, less func(T, T) bool) int {
+	runHi := lo + 1
+	if runHi == hi {
+		return 1
+	}
+	if less(a[runHi], a[lo]) {
+		// 严格降序，翻转后才能保持稳定
+		runHi++
+		for runHi < hi && less(a[runHi], a[runHi-1]) {
+			runHi++
+		}
+		for i, j := lo, runHi-1; i < j; i, j = i+1, j-1 {
+			a[i], a[j] = a[j], a[i]
+		}
+	} else {
+		runHi++
+		for runHi < hi && !less(a[runHi], a[runHi-1]) {
+			runHi++
+		}
+	}
+	return runHi - lo
+}
 
-		i, j, k := 0, 0, l
-		for i < len(left) && j < len(right) {
-			if !less(right[j], left[i]) {
-				arr[k] = left[i]
-				i++
+// binaryInsertionSort 对 a[lo:hi] 排序，其中 a[lo:start] 已经有序
+func binaryInsertionSort[T any](a []T, lo, hi, start int, less func(T, T) bool) {
+	if start == lo {
+		start++
+	}
+	for ; start < hi; start++ {
+		pivot := a[start]
+		left, right := lo, start
+		for left < right {
+			mid := int(uint(left+right) >> 1)
+			if less(pivot, a[mid]) {
+				right = mid
 			} else {
-				arr[k] = right[j]
-				j++
+				left = mid + 1
+			}
+		}
+		copy(a[left+1:start+1], a[left:start])
+		a[left] = pivot
+	}
+}
+
+func (ts *timSorter[T]) pushRun(base, len int) {
+	ts.runs = append(ts.runs, timRun{base: base, len: len})
+}
+
+// mergeCollapse 合并栈顶的段直到满足不变式：
+// runs[i-2].len > runs[i-1].len + runs[i].len 且 runs[i-1].len > runs[i].len
+func (ts *timSorter[T]) mergeCollapse() {
+	for len(ts.runs) > 1 {
+		runs := ts.runs
+		n := len(runs) - 2
+		if (n > 0 && runs[n-1].len <= runs[n].len+runs[n+1].len) ||
+			(n > 1 && runs[n-2].len <= runs[n-1].len+runs[n].len) {
+			if runs[n-1].len < runs[n+1].len {
+				n--
 			}
-			k++
+		} else if runs[n].len > runs[n+1].len {
+			break
 		}
+		ts.mergeAt(n)
+	}
+}
 
-		for i < len(left) {
-			arr[k] = left[i]
-			i++
-			k++
+// mergeForceCollapse 合并栈中剩余的所有段
+func (ts *timSorter[T]) mergeForceCollapse() {
+	for len(ts.runs) > 1 {
+		n := len(ts.runs) - 2
+		if n > 0 && ts.runs[n-1].len < ts.runs[n+1].len {
+			n--
 		}
+		ts.mergeAt(n)
+	}
+}
+
+// mergeAt 合并栈中第 i 和 i+1 个段
+func (ts *timSorter[T]) mergeAt(i int) {
+	a := ts.a
+	base1, len1 := ts.runs[i].base, ts.runs[i].len
+	base2, len2 := ts.runs[i+1].base, ts.runs[i+1].len
 
-		for j < len(right) {
-			arr[k] = right[j]
-			j++
-			k++
+	ts.runs[i].len = len1 + len2
+	if i == len(ts.runs)-3 {
+		ts.runs[i+1] = ts.runs[i+2]
+	}
+	ts.runs = ts.runs[:len(ts.runs)-1]
+
+	// 第二段的首元素在第一段中的位置之前的元素已经就位
+	k := gallopRight(a[base2], a, base1, len1, 0, ts.less)
+	base1 += k
+	len1 -= k
+	if len1 == 0 {
+		return
+	}
+	// 第一段的尾元素在第二段中的位置之后的元素已经就位
+	len2 = gallopLeft(a[base1+len1-1], a, base2, len2, len2-1, ts.less)
+	if len2 == 0 {
+		return
+	}
+
+	if len1 <= len2 {
+		ts.mergeLo(base1, len1, base2, len2)
+	} else {
+		ts.mergeHi(base1, len1, base2, len2)
+	}
+}
+
+// ensureBuf 返回至少 n 个元素的合并缓冲区
+func (ts *timSorter[T]) ensureBuf(n int) []T {
+	if cap(ts.buf) < n {
+		size := len(ts.a) / 2
+		if size < n {
+			size = n
 		}
+		ts.buf = make([]T, size)
 	}
+	return ts.buf[:n]
+}
 
-	// 对小块使用插入排序
-	for i := 0; i < len(arr); i += runLength {
-		end := i + runLength - 1
-		if end >= len(arr) {
-			end = len(arr) - 1
+// gallopLeft 返回 key 在有序区间 a[base:base+length] 中最左侧的插入位置，从 hint 处开始指数搜索
+func gallopLeft[T any](key T, a []T, base, length, hint int, less func(T, T) bool) int {
+	lastOfs, ofs := 0, 1
+	if less(a[base+hint], key) {
+		// 向右搜索，直到 a[base+hint+lastOfs] < key <= a[base+hint+ofs]
+		maxOfs := length - hint
+		for ofs < maxOfs && less(a[base+hint+ofs], key) {
+			lastOfs = ofs
+			ofs = ofs<<1 + 1
+			if ofs <= 0 {
+				ofs = maxOfs
+			}
+		}
+		if ofs > maxOfs {
+			ofs = maxOfs
+		}
+		lastOfs += hint
+		ofs += hint
+	} else {
+		// 向左搜索，直到 a[base+hint-ofs] < key <= a[base+hint-lastOfs]
+		maxOfs := hint + 1
+		for ofs < maxOfs && !less(a[base+hint-ofs], key) {
+			lastOfs = ofs
+			ofs = ofs<<1 + 1
+			if ofs <= 0 {
+				ofs = maxOfs
+			}
+		}
+		if ofs > maxOfs {
+			ofs = maxOfs
 		}
-		insertionSort(arr, i, end)
+		lastOfs, ofs = hint-ofs, hint-lastOfs
 	}
+	// 在 (lastOfs, ofs] 中二分
+	lastOfs++
+	for lastOfs < ofs {
+		m := lastOfs + (ofs-lastOfs)>>1
+		if less(a[base+m], key) {
+			lastOfs = m + 1
+		} else {
+			ofs = m
+		}
+	}
+	return ofs
+}
 
-	// 合并排序后的小块
-	for size := runLength; size < len(arr); size *= 2 {
-		for start := 0; start < len(arr); start += size * 2 {
-			mid := start + size - 1
-			end := start + size*2 - 1
-			if mid >= len(arr) {
-				mid = len(arr) - 1
+// gallopRight 返回 key 在有序区间 a[base:base+length] 中最右侧的插入位置，从 hint 处开始指数搜索
+func gallopRight[T any](key T, a []T, base, length, hint int, less func(T, T) bool) int {
+	lastOfs, ofs := 0, 1
+	if less(key, a[base+hint]) {
+		// 向左搜索，直到 a[base+hint-ofs] <= key < a[base+hint-lastOfs]
+		maxOfs := hint + 1
+		for ofs < maxOfs && less(key, a[base+hint-ofs]) {
+			lastOfs = ofs
+			ofs = ofs<<1 + 1
+			if ofs <= 0 {
+				ofs = maxOfs
 			}
-			if end >= len(arr) {
-				end = len(arr) - 1
+		}
+		if ofs > maxOfs {
+			ofs = maxOfs
+		}
+		lastOfs, ofs = hint-ofs, hint-lastOfs
+	} else {
+		// 向右搜索，直到 a[base+hint+lastOfs] <= key < a[base+hint+ofs]
+		maxOfs := length - hint
+		for ofs < maxOfs && !less(key, a[base+hint+ofs]) {
+			lastOfs = ofs
+			ofs = ofs<<1 + 1
+			if ofs <= 0 {
+				ofs = maxOfs
 			}
-			if mid > start {
-				merge(arr, start, mid, end)
+		}
+		if ofs > maxOfs {
+			ofs = maxOfs
+		}
+		lastOfs += hint
+		ofs += hint
+	}
+	// 在 (lastOfs, ofs] 中二分
+	lastOfs++
+	for lastOfs < ofs {
+		m := lastOfs + (ofs-lastOfs)>>1
+		if less(key, a[base+m]) {
+			ofs = m
+		} else {
+			lastOfs = m + 1
+		}
+	}
+	return ofs
+}
+
+// mergeLo 在 len1 <= len2 时从左向右合并，第一段复制到缓冲区
+// 调用前保证 a[base2] 小于 a[base1]，且 a[base1+len1-1] 大于第二段所有元素
+func (ts *timSorter[T]) mergeLo(base1, len1, base2, len2 int) {
+	a, less := ts.a, ts.less
+	tmp := ts.ensureBuf(len1)
+	copy(tmp, a[base1:base1+len1])
+
+	cursor1, cursor2, dest := 0, base2, base1
+	a[dest] = a[cursor2]
+	dest++
+	cursor2++
+	len2--
+	if len2 == 0 {
+		copy(a[dest:dest+len1], tmp[cursor1:cursor1+len1])
+		return
+	}
+	if len1 == 1 {
+		copy(a[dest:dest+len2], a[cursor2:cursor2+len2])
+		a[dest+len2] = tmp[cursor1]
+		return
+	}
+
+	minGallop := ts.minGallop
+outer:
+	for {
+		count1, count2 := 0, 0
+		// 逐个比较，直到某一侧连续胜出 minGallop 次
+		for {
+			if less(a[cursor2], tmp[cursor1]) {
+				a[dest] = a[cursor2]
+				dest++
+				cursor2++
+				count2++
+				count1 = 0
+				len2--
+				if len2 == 0 {
+					break outer
+				}
+			} else {
+				a[dest] = tmp[cursor1]
+				dest++
+				cursor1++
+				count1++
+				count2 = 0
+				len1--
+				if len1 == 1 {
+					break outer
+				}
+			}
+			if count1|count2 >= minGallop {
+				break
 			}
 		}
+		// galloping 模式，成块搬运
+		for {
+			count1 = gallopRight(a[cursor2], tmp, cursor1, len1, 0, less)
+			if count1 != 0 {
+				copy(a[dest:dest+count1], tmp[cursor1:cursor1+count1])
+				dest += count1
+				cursor1 += count1
+				len1 -= count1
+				if len1 <= 1 {
+					break outer
+				}
+			}
+			a[dest] = a[cursor2]
+			dest++
+			cursor2++
+			len2--
+			if len2 == 0 {
+				break outer
+			}
+
+			count2 = gallopLeft(tmp[cursor1], a, cursor2, len2, 0, less)
+			if count2 != 0 {
+				copy(a[dest:dest+count2], a[cursor2:cursor2+count2])
+				dest += count2
+				cursor2 += count2
+				len2 -= count2
+				if len2 == 0 {
+					break outer
+				}
+			}
+			a[dest] = tmp[cursor1]
+			dest++
+			cursor1++
+			len1--
+			if len1 == 1 {
+				break outer
+			}
+			minGallop--
+			if count1 < timSortMinGallop && count2 < timSortMinGallop {
+				break
+			}
+		}
+		if minGallop < 0 {
+			minGallop = 0
+		}
+		// 离开 galloping 模式，提高下次进入的门槛
+		minGallop += 2
+	}
+	if minGallop < 1 {
+		minGallop = 1
 	}
+	ts.minGallop = minGallop
 
-	return arr
+	switch {
+	case len1 == 1:
+		copy(a[dest:dest+len2], a[cursor2:cursor2+len2])
+		a[dest+len2] = tmp[cursor1]
+	case len1 == 0:
+		panic("sort: comparison function violates its general contract")
+	default:
+		copy(a[dest:dest+len1], tmp[cursor1:cursor1+len1])
+	}
+}
+
+// mergeHi 在 len1 > len2 时从右向左合并，第二段复制到缓冲区
+// 调用前保证 a[base2] 小于 a[base1]，且 a[base1+len1-1] 大于第二段所有元素
+func (ts *timSorter[T]) mergeHi(base1, len1, base2, len2 int) {
+	a, less := ts.a, ts.less
+	tmp := ts.ensureBuf(len2)
+	copy(tmp, a[base2:base2+len2])
+
+	cursor1, cursor2, dest := base1+len1-1, len2-1, base2+len2-1
+	a[dest] = a[cursor1]
+	dest--
+	cursor1--
+	len1--
+	if len1 == 0 {
+		copy(a[dest-(len2-1):dest+1], tmp[:len2])
+		return
+	}
+	if len2 == 1 {
+		dest -= len1
+		cursor1 -= len1
+		copy(a[dest+1:dest+1+len1], a[cursor1+1:cursor1+1+len1])
+		a[dest] = tmp[cursor2]
+		return
+	}
+
+	minGallop := ts.minGallop
+outer:
+	for {
+		count1, count2 := 0, 0
+		// 逐个比较，直到某一侧连续胜出 minGallop 次
+		for {
+			if less(tmp[cursor2], a[cursor1]) {
+				a[dest] = a[cursor1]
+				dest--
+				cursor1--
+				count1++
+				count2 = 0
+				len1--
+				if len1 == 0 {
+					break outer
+				}
+			} else {
+				a[dest] = tmp[cursor2]
+				dest--
+				cursor2--
+				count2++
+				count1 = 0
+				len2--
+				if len2 == 1 {
+					break outer
+				}
+			}
+			if count1|count2 >= minGallop {
+				break
+			}
+		}
+		// galloping 模式，成块搬运
+		for {
+			count1 = len1 - gallopRight(tmp[cursor2], a, base1, len1, len1-1, less)
+			if count1 != 0 {
+				dest -= count1
+				cursor1 -= count1
+				len1 -= count1
+				copy(a[dest+1:dest+1+count1], a[cursor1+1:cursor1+1+count1])
+				if len1 == 0 {
+					break outer
+				}
+			}
+			a[dest] = tmp[cursor2]
+			dest--
+			cursor2--
+			len2--
+			if len2 == 1 {
+				break outer
+			}
+
+			count2 = len2 - gallopLeft(a[cursor1], tmp, 0, len2, len2-1, less)
+			if count2 != 0 {
+				dest -= count2
+				cursor2 -= count2
+				len2 -= count2
+				copy(a[dest+1:dest+1+count2], tmp[cursor2+1:cursor2+1+count2])
+				if len2 <= 1 {
+					break outer
+				}
+			}
+			a[dest] = a[cursor1]
+			dest--
+			cursor1--
+			len1--
+			if len1 == 0 {
+				break outer
+			}
+			minGallop--
+			if count1 < timSortMinGallop && count2 < timSortMinGallop {
+				break
+			}
+		}
+		if minGallop < 0 {
+			minGallop = 0
+		}
+		// 离开 galloping 模式，提高下次进入的门槛
+		minGallop += 2
+	}
+	if minGallop < 1 {
+		minGallop = 1
+	}
+	ts.minGallop = minGallop
+
+	switch {
+	case len2 == 1:
+		dest -= len1
+		cursor1 -= len1
+		copy(a[dest+1:dest+1+len1], a[cursor1+1:cursor1+1+len1])
+		a[dest] = tmp[cursor2]
+	case len2 == 0:
+		panic("sort: comparison function violates its general contract")
+	default:
+		copy(a[dest-(len2-1):dest+1], tmp[:len2])
+	}
 }
@@ -0,0 +1,88 @@
+package y
+
+import (
+	"reflect"
+	"strings"
+)
+
+type where struct {
+	props []*selectorProp
+}
+
+// Where 将 Pick 选择器中的条件表达式编译为过滤条件，可传给 Filter、Del、Partition 等
+// 表达式只在创建时解析一次，语法与 Pick 的 [] 内部一致，多个条件之间为且的关系，同一个字段也可以有多个条件：
+//
+//	y.Filter(users, y.Where("Age>=18,Name*=Li"))
+//	y.Filter(users, y.Where("Age>=18,Age<21"))
+//	y.Filter(users, y.Where("[age>10,name*='张']"))
+//
+// 字段名优先精确匹配结构体字段或映射的键，找不到时忽略大小写再匹配一次
+// 元素中不存在的字段视为不满足条件
+func Where(expr string) *where {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "[") && strings.HasSuffix(expr, "]") {
+		expr = expr[1 : len(expr)-1]
+	}
+	node := &selectorNode{}
+	new(selector).parseExpr(node, expr)
+	return &where{props: node.props}
+}
+
+// match 判断元素是否满足全部条件
+func (w *where) match(v any) bool {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return len(w.props) == 0
+		}
+		rv = rv.Elem()
+	}
+	for _, prop := range w.props {
+		vv, ok := lookupField(rv, prop.key)
+		if !ok || !matchProp(prop, vv) {
+			return false
+		}
+	}
+	return true
+}
+
+// lookupField 按名称查找结构体字段或映射的值，精确匹配失败时忽略大小写
+func lookupField(rv reflect.Value, key string) (any, bool) {
+	switch rv.Kind() {
+	case reflect.Struct:
+		if field, ok := rv.Type().FieldByName(key); ok && len(field.Index) == 1 {
+			fv := rv.Field(field.Index[0])
+			if fv.CanInterface() {
+				return fv.Interface(), true
+			}
+		}
+		for i := 0; i < rv.NumField(); i++ {
+			fv := rv.Field(i)
+			if fv.CanInterface() && strings.EqualFold(rv.Type().Field(i).Name, key) {
+				return fv.Interface(), true
+			}
+		}
+	case reflect.Map:
+		if rv.Type().Key().Kind() == reflect.String {
+			vv := rv.MapIndex(reflect.ValueOf(key).Convert(rv.Type().Key()))
+			if vv.IsValid() {
+				return vv.Interface(), true
+			}
+		}
+		var found reflect.Value
+		iter := rv.MapRange()
+		for iter.Next() {
+			kStr := toString(iter.Key().Interface())
+			if kStr == key {
+				return iter.Value().Interface(), true
+			}
+			if !found.IsValid() && strings.EqualFold(kStr, key) {
+				found = iter.Value()
+			}
+		}
+		if found.IsValid() {
+			return found.Interface(), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,85 @@
+package y
+
+// Zip 将两个切片按下标组合为 Tuple2，长度取较短的一方
+func Zip[A, B any](a []A, b []B) []Tuple2[A, B] {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	result := make([]Tuple2[A, B], n)
+	for i := 0; i < n; i++ {
+		result[i] = T(a[i], b[i])
+	}
+	return result
+}
+
+// Zip3 将三个切片按下标组合为 Tuple3，长度取最短的一方
+func Zip3[A, B, C any](a []A, b []B, c []C) []Tuple3[A, B, C] {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	if len(c) < n {
+		n = len(c)
+	}
+	result := make([]Tuple3[A, B, C], n)
+	for i := 0; i < n; i++ {
+		result[i] = T3(a[i], b[i], c[i])
+	}
+	return result
+}
+
+// Unzip 将 Tuple2 切片拆分为两个切片
+func Unzip[A, B any](arr []Tuple2[A, B]) ([]A, []B) {
+	a := make([]A, len(arr))
+	b := make([]B, len(arr))
+	for i, t := range arr {
+		a[i], b[i] = t.a, t.b
+	}
+	return a, b
+}
+
+// Unzip3 将 Tuple3 切片拆分为三个切片
+func Unzip3[A, B, C any](arr []Tuple3[A, B, C]) ([]A, []B, []C) {
+	a := make([]A, len(arr))
+	b := make([]B, len(arr))
+	c := make([]C, len(arr))
+	for i, t := range arr {
+		a[i], b[i], c[i] = t.a, t.b, t.c
+	}
+	return a, b, c
+}
+
+// Enumerate 返回 (下标, 元素) 组成的 Tuple2 切片
+func Enumerate[V any](arr []V) []Tuple2[int, V] {
+	result := make([]Tuple2[int, V], len(arr))
+	for i, v := range arr {
+		result[i] = T(i, v)
+	}
+	return result
+}
+
+// CrossJoin 返回两个切片的笛卡尔积，按 a 的顺序排列
+func CrossJoin[A, B any](a []A, b []B) []Tuple2[A, B] {
+	result := make([]Tuple2[A, B], 0, len(a)*len(b))
+	for _, x := range a {
+		for _, y := range b {
+			result = append(result, T(x, y))
+		}
+	}
+	return result
+}
+
+// Flex2 对 Tuple2 切片做映射，回调直接接收解构后的字段，选项同 Flex
+func Flex2[A, B, R any](arr []Tuple2[A, B], fn func(A, B, int) R, opts ...Option) []R {
+	return Flex(arr, func(t Tuple2[A, B], i int) R {
+		return fn(t.a, t.b, i)
+	}, opts...)
+}
+
+// Flex3 对 Tuple3 切片做映射，回调直接接收解构后的字段，选项同 Flex
+func Flex3[A, B, C, R any](arr []Tuple3[A, B, C], fn func(A, B, C, int) R, opts ...Option) []R {
+	return Flex(arr, func(t Tuple3[A, B, C], i int) R {
+		return fn(t.a, t.b, t.c, i)
+	}, opts...)
+}
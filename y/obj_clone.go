@@ -73,52 +73,59 @@ func safeCall(fn func() error) (err error) {
 
 // deepCloneValue 递归深拷贝reflect.Value
 func deepCloneValue(src reflect.Value, visited map[uintptr]reflect.Value) reflect.Value {
-	if !src.IsValid() {
-		return reflect.Value{}
-	}
+	return walkValue[reflect.Value](src, cloner{visited})
+}
 
-	srcType := src.Type()
+// cloner 是深拷贝的 valueVisitor
+type cloner struct {
+	visited map[uintptr]reflect.Value
+}
 
-	switch src.Kind() {
-	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
-		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128, reflect.String:
-		// 基本类型直接复制
-		return src
+func (c cloner) invalid() reflect.Value {
+	return reflect.Value{}
+}
 
-	case reflect.Array:
-		return cloneArray(src, visited)
+func (c cloner) basic(src reflect.Value) reflect.Value {
+	// 基本类型直接复制
+	return src
+}
 
-	case reflect.Slice:
-		return cloneSlice(src, visited)
+func (c cloner) array(src reflect.Value) reflect.Value {
+	return cloneArray(src, c.visited)
+}
 
-	case reflect.Map:
-		return cloneMap(src, visited)
+func (c cloner) slice(src reflect.Value) reflect.Value {
+	return cloneSlice(src, c.visited)
+}
 
-	case reflect.Ptr:
-		return clonePtr(src, visited)
+func (c cloner) mapValue(src reflect.Value) reflect.Value {
+	return cloneMap(src, c.visited)
+}
 
-	case reflect.Struct:
-		return cloneStruct(src, visited)
+func (c cloner) ptr(src reflect.Value) reflect.Value {
+	return clonePtr(src, c.visited)
+}
 
-	case reflect.Interface:
-		return cloneInterface(src, visited)
+func (c cloner) structValue(src reflect.Value) reflect.Value {
+	return cloneStruct(src, c.visited)
+}
 
-	case reflect.Chan:
-		return cloneChan(src, visited)
+func (c cloner) iface(src reflect.Value) reflect.Value {
+	return cloneInterface(src, c.visited)
+}
 
-	case reflect.Func:
-		// 函数类型直接返回原值（函数无法深拷贝）
-		return src
+func (c cloner) chanValue(src reflect.Value) reflect.Value {
+	return cloneChan(src, c.visited)
+}
 
-	case reflect.UnsafePointer:
-		// unsafe.Pointer 直接复制
-		return src
+func (c cloner) funcValue(src reflect.Value) reflect.Value {
+	// 函数类型直接返回原值（函数无法深拷贝）
+	return src
+}
 
-	default:
-		// 其他类型直接返回零值
-		return reflect.Zero(srcType)
-	}
+func (c cloner) unsafePointer(src reflect.Value) reflect.Value {
+	// unsafe.Pointer 直接复制
+	return src
 }
 
 // cloneArray 拷贝数组
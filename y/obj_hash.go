@@ -0,0 +1,183 @@
+package y
+
+import (
+	"math"
+	"reflect"
+)
+
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// Hash 计算任意值的结构化哈希，包括私有字段
+// 与 reflect.DeepEqual 一致：深度相等的值哈希值一定相同；切片、映射等不可比较的类型同样适用
+// 映射的哈希与遍历顺序无关，循环引用只会计算一次
+func Hash(v any) uint64 {
+	h := &structHasher{sum: fnvOffset64, visited: make(map[uintptr]bool)}
+	h.hashValue(reflect.ValueOf(v))
+	return h.sum
+}
+
+// structHasher 基于 FNV-1a 的结构化哈希
+type structHasher struct {
+	sum     uint64
+	visited map[uintptr]bool
+}
+
+func (h *structHasher) writeUint64(x uint64) {
+	for i := 0; i < 8; i++ {
+		h.sum ^= x & 0xff
+		h.sum *= fnvPrime64
+		x >>= 8
+	}
+}
+
+func (h *structHasher) writeString(s string) {
+	for i := 0; i < len(s); i++ {
+		h.sum ^= uint64(s[i])
+		h.sum *= fnvPrime64
+	}
+	h.writeUint64(uint64(len(s)))
+}
+
+// hashValue 递归计算reflect.Value的哈希，通过 walkValue 与 deepCloneValue 共用遍历
+func (h *structHasher) hashValue(src reflect.Value) {
+	if src.IsValid() {
+		h.writeUint64(uint64(src.Kind()))
+	}
+	walkValue[struct{}](src, h)
+}
+
+func (h *structHasher) invalid() struct{} {
+	h.writeUint64(0)
+	return struct{}{}
+}
+
+func (h *structHasher) basic(src reflect.Value) struct{} {
+	switch src.Kind() {
+	case reflect.Bool:
+		if src.Bool() {
+			h.writeUint64(1)
+		} else {
+			h.writeUint64(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		h.writeUint64(uint64(src.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		h.writeUint64(src.Uint())
+	case reflect.Float32, reflect.Float64:
+		h.writeFloat(src.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := src.Complex()
+		h.writeFloat(real(c))
+		h.writeFloat(imag(c))
+	case reflect.String:
+		h.writeString(src.String())
+	}
+	return struct{}{}
+}
+
+func (h *structHasher) array(src reflect.Value) struct{} {
+	h.hashElems(src)
+	return struct{}{}
+}
+
+func (h *structHasher) slice(src reflect.Value) struct{} {
+	if src.IsNil() {
+		h.writeUint64(0)
+	} else {
+		h.hashElems(src)
+	}
+	return struct{}{}
+}
+
+func (h *structHasher) mapValue(src reflect.Value) struct{} {
+	h.hashMap(src)
+	return struct{}{}
+}
+
+func (h *structHasher) ptr(src reflect.Value) struct{} {
+	if src.IsNil() {
+		h.writeUint64(0)
+		return struct{}{}
+	}
+	// 检查循环引用
+	addr := src.Pointer()
+	if h.visited[addr] {
+		return struct{}{}
+	}
+	h.visited[addr] = true
+	h.hashValue(src.Elem())
+	delete(h.visited, addr)
+	return struct{}{}
+}
+
+func (h *structHasher) structValue(src reflect.Value) struct{} {
+	h.writeString(src.Type().String())
+	for i := 0; i < src.NumField(); i++ {
+		h.hashValue(src.Field(i))
+	}
+	return struct{}{}
+}
+
+func (h *structHasher) iface(src reflect.Value) struct{} {
+	if src.IsNil() {
+		h.writeUint64(0)
+		return struct{}{}
+	}
+	h.writeString(src.Elem().Type().String())
+	h.hashValue(src.Elem())
+	return struct{}{}
+}
+
+func (h *structHasher) chanValue(src reflect.Value) struct{} {
+	h.writeUint64(uint64(src.Pointer()))
+	return struct{}{}
+}
+
+func (h *structHasher) funcValue(src reflect.Value) struct{} {
+	// 函数只在都为 nil 时深度相等
+	if src.IsNil() {
+		h.writeUint64(0)
+	}
+	return struct{}{}
+}
+
+func (h *structHasher) unsafePointer(src reflect.Value) struct{} {
+	h.writeUint64(uint64(src.Pointer()))
+	return struct{}{}
+}
+
+func (h *structHasher) writeFloat(f float64) {
+	if f == 0 {
+		// +0 与 -0 相等
+		f = 0
+	}
+	h.writeUint64(math.Float64bits(f))
+}
+
+func (h *structHasher) hashElems(src reflect.Value) {
+	h.writeUint64(uint64(src.Len()))
+	for i := 0; i < src.Len(); i++ {
+		h.hashValue(src.Index(i))
+	}
+}
+
+// hashMap 分别计算每个键值对的哈希再求和，结果与遍历顺序无关
+func (h *structHasher) hashMap(src reflect.Value) {
+	if src.IsNil() {
+		h.writeUint64(0)
+		return
+	}
+	var sum uint64
+	iter := src.MapRange()
+	for iter.Next() {
+		entry := &structHasher{sum: fnvOffset64, visited: h.visited}
+		entry.hashValue(iter.Key())
+		entry.hashValue(iter.Value())
+		sum += entry.sum
+	}
+	h.writeUint64(uint64(src.Len()))
+	h.writeUint64(sum)
+}
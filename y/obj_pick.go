@@ -2,6 +2,7 @@ package y
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"reflect"
 	"sort"
@@ -18,7 +19,7 @@ type selector struct {
 
 type selectorNode struct {
 	key   string
-	props map[string]*selectorProp
+	props []*selectorProp // 按出现顺序保存，全部满足才算匹配
 }
 
 var (
@@ -38,66 +39,36 @@ type selectorProp struct {
 	value any
 }
 
+// setProp 追加一个条件，同一个字段可以有多个条件，例如 age>=18,age<30
 func (s *selectorNode) setProp(key string, value string) {
-	if strings.HasPrefix(value, opLike) {
-		s.props[key] = &selectorProp{
-			key:   key,
-			op:    opLike,
-			value: value[2:],
-		}
-	} else if strings.HasPrefix(value, opNot) {
-		s.props[key] = &selectorProp{
-			key:   key,
-			op:    opNot,
-			value: value[2:],
-		}
-	} else if strings.HasPrefix(value, opGe) {
-		val, ok := toFloat64(value[2:])
-		s.props[key] = &selectorProp{
-			key:   key,
-			op:    opGe,
-			value: val,
-		}
-		if !ok {
-			s.props[key].op = opErr
-		}
-	} else if strings.HasPrefix(value, opGt) {
-		val, ok := toFloat64(value[1:])
-		s.props[key] = &selectorProp{
-			key:   key,
-			op:    opGt,
-			value: val,
-		}
-		if !ok {
-			s.props[key].op = opErr
-		}
-	} else if strings.HasPrefix(value, opLe) {
-		val, ok := toFloat64(value[2:])
-		s.props[key] = &selectorProp{
-			key:   key,
-			op:    opLe,
-			value: val,
-		}
-		if !ok {
-			s.props[key].op = opErr
-		}
-	} else if strings.HasPrefix(value, opLt) {
-		val, ok := toFloat64(value[1:])
-		s.props[key] = &selectorProp{
-			key:   key,
-			op:    opLt,
-			value: val,
-		}
+	prop := &selectorProp{
+		key: key,
+	}
+	var number string
+	switch {
+	case strings.HasPrefix(value, opLike):
+		prop.op, prop.value = opLike, value[2:]
+	case strings.HasPrefix(value, opNot):
+		prop.op, prop.value = opNot, value[2:]
+	case strings.HasPrefix(value, opGe):
+		prop.op, number = opGe, value[2:]
+	case strings.HasPrefix(value, opGt):
+		prop.op, number = opGt, value[1:]
+	case strings.HasPrefix(value, opLe):
+		prop.op, number = opLe, value[2:]
+	case strings.HasPrefix(value, opLt):
+		prop.op, number = opLt, value[1:]
+	default:
+		prop.op, prop.value = opEqual, value[1:]
+	}
+	if prop.value == nil {
+		val, ok := toFloat64(number)
+		prop.value = val
 		if !ok {
-			s.props[key].op = opErr
-		}
-	} else {
-		s.props[key] = &selectorProp{
-			key:   key,
-			op:    opEqual,
-			value: value[1:],
+			prop.op = opErr
 		}
 	}
+	s.props = append(s.props, prop)
 }
 
 func (s *selector) parse() []*selectorNode {
@@ -112,8 +83,7 @@ func (s *selector) parse() []*selectorNode {
 		if s.isWord(c) {
 			if current == nil {
 				current = &selectorNode{
-					key:   "",
-					props: make(map[string]*selectorProp),
+					key: "",
 				}
 				nodes = append(nodes, current)
 			}
@@ -130,8 +100,7 @@ func (s *selector) parse() []*selectorNode {
 			s.idx++
 			if current == nil {
 				current = &selectorNode{
-					key:   "",
-					props: make(map[string]*selectorProp),
+					key: "",
 				}
 				nodes = append(nodes, current)
 			} else {
@@ -278,67 +247,42 @@ type picker[T any] struct {
 
 func (p *picker[T]) matchProps(kvMap map[string]any, keyWrapper *keyWrapper) {
 	node := p.nodes[keyWrapper.matchPos]
-	for k, prop := range node.props {
-		if vv, ok := kvMap[k]; ok {
-			switch prop.op {
-			case opErr:
+	for _, prop := range node.props {
+		if vv, ok := kvMap[prop.key]; ok {
+			if !matchProp(prop, vv) {
 				return
-			case opEqual:
-				if toString(vv) != prop.value {
-					return
-				}
-				keyWrapper.propsMatched++
-			case opLike:
-				if !strings.Contains(toString(vv), prop.value.(string)) {
-					return
-				}
-				keyWrapper.propsMatched++
-			case opNot:
-				if toString(vv) == prop.value {
-					return
-				}
-				keyWrapper.propsMatched++
-			case opGt:
-				val, ok := toFloat64(vv)
-				if !ok {
-					return
-				}
-				if val <= prop.value.(float64) {
-					return
-				}
-				keyWrapper.propsMatched++
-			case opGe:
-				val, ok := toFloat64(vv)
-				if !ok {
-					return
-				}
-				if val < prop.value.(float64) {
-					return
-				}
-				keyWrapper.propsMatched++
-			case opLt:
-				val, ok := toFloat64(vv)
-				if !ok {
-					return
-				}
-				if val >= prop.value.(float64) {
-					return
-				}
-				keyWrapper.propsMatched++
-			case opLe:
-				val, ok := toFloat64(vv)
-				if !ok {
-					return
-				}
-				if val > prop.value.(float64) {
-					return
-				}
-				keyWrapper.propsMatched++
 			}
+			keyWrapper.propsMatched++
 		}
 	}
 }
 
+// matchProp 判断单个值是否满足属性条件
+func matchProp(prop *selectorProp, vv any) bool {
+	switch prop.op {
+	case opEqual:
+		return toString(vv) == prop.value
+	case opLike:
+		return strings.Contains(toString(vv), prop.value.(string))
+	case opNot:
+		return toString(vv) != prop.value
+	case opGt:
+		val, ok := toFloat64(vv)
+		return ok && val > prop.value.(float64)
+	case opGe:
+		val, ok := toFloat64(vv)
+		return ok && val >= prop.value.(float64)
+	case opLt:
+		val, ok := toFloat64(vv)
+		return ok && val < prop.value.(float64)
+	case opLe:
+		val, ok := toFloat64(vv)
+		return ok && val <= prop.value.(float64)
+	}
+	// opErr
+	return false
+}
+
 // func (p *picker[T]) checkAllPropsMatched() bool {
 // 	var pos = -1
 // 	for _, keyWrapper := range p.stack {
@@ -399,34 +343,7 @@ func (p *picker[T]) walk(dest any, kk string) {
 	// 	ref = reflect.New(v.Type())
 	// 	ref.Elem().Set(v)
 	// }
-	var kvMap map[string]any
-	if v.Kind() == reflect.Map || v.Kind() == reflect.Struct || v.Kind() == reflect.Slice {
-		kvMap = make(map[string]any)
-	}
-
-	switch v.Kind() {
-	case reflect.Map:
-		for _, k := range v.MapKeys() {
-			kk := k.Interface()
-			vv := v.MapIndex(k)
-			kStr := toString(kk)
-			kvMap[kStr] = vv.Interface()
-		}
-	case reflect.Slice:
-		for i := 0; i < v.Len(); i++ {
-			vv := v.Index(i)
-			kStr := fmt.Sprintf("%d", i)
-			kvMap[kStr] = vv.Interface()
-		}
-	case reflect.Struct:
-		for i := 0; i < v.NumField(); i++ {
-			vv := v.Field(i)
-			if vv.CanInterface() {
-				kStr := v.Type().Field(i).Name
-				kvMap[kStr] = vv.Interface()
-			}
-		}
-	}
+	kvMap := toKvMap(v)
 
 	oldPos := keyWrapper.matchPos
 	defer func() {
@@ -467,6 +384,39 @@ func (p *picker[T]) walk(dest any, kk string) {
 	}
 }
 
+// toKvMap 将映射、切片、结构体展开为 键->值，其他类型返回 nil
+func toKvMap(v reflect.Value) map[string]any {
+	var kvMap map[string]any
+	if v.Kind() == reflect.Map || v.Kind() == reflect.Struct || v.Kind() == reflect.Slice {
+		kvMap = make(map[string]any)
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			kk := k.Interface()
+			vv := v.MapIndex(k)
+			kStr := toString(kk)
+			kvMap[kStr] = vv.Interface()
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			vv := v.Index(i)
+			kStr := fmt.Sprintf("%d", i)
+			kvMap[kStr] = vv.Interface()
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			vv := v.Field(i)
+			if vv.CanInterface() {
+				kStr := v.Type().Field(i).Name
+				kvMap[kStr] = vv.Interface()
+			}
+		}
+	}
+	return kvMap
+}
+
 func (p *picker[T]) pushResult(dest any) {
 	var ret any = dest
 	if v, ok := dest.(reflect.Value); ok {
@@ -497,6 +447,7 @@ func pick[T any](src any, rule string) []T {
 
 // 从任意对象中收集元素
 func Pick[T any](src any, rules ...any) (result []T) {
+	checkOptions("Pick", rules, pickOptions)
 	var shouldDistinct = false
 	defer func() {
 		if shouldDistinct {
@@ -513,11 +464,16 @@ func Pick[T any](src any, rules ...any) (result []T) {
 		}
 	}()
 	var selectors []string
+	var pool *WorkerPool
 	for _, rule := range rules {
 		if rule == UseDistinct {
 			shouldDistinct = true
 			continue
 		}
+		if v, ok := rule.(poolOption); ok {
+			pool = v.pool
+			continue
+		}
 		if v, ok := rule.(string); ok {
 			selectors = append(selectors, v)
 		}
@@ -530,8 +486,27 @@ func Pick[T any](src any, rules ...any) (result []T) {
 		return
 	}
 
+	if pool != nil {
+		// 在共享的协程池中执行
+		futures := make([]*Future[[]T], len(selectors))
+		for i, selector := range selectors {
+			selector := selector
+			futures[i] = Submit(context.Background(), pool, func(context.Context) ([]T, error) {
+				return pick[T](src, selector), nil
+			})
+		}
+		for _, f := range futures {
+			r, err := f.Await(context.Background())
+			if err != nil {
+				return nil
+			}
+			result = append(result, r...)
+		}
+		return
+	}
+
 	var g WaitGroup
-	var ret = make([][]T, 0, len(selectors))
+	var ret = make([][]T, len(selectors))
 	var mu sync.Mutex
 	for i, selector := range selectors {
 		i := i
@@ -631,7 +606,7 @@ func toString(value any) string {
 	switch v := value.(type) {
 	case string:
 		return v
-	case int, int64, float64, bool:
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, bool:
 		return fmt.Sprintf("%v", v)
 	default:
 		return ""
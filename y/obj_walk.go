@@ -0,0 +1,62 @@
+package y
+
+import "reflect"
+
+// valueVisitor 处理 walkValue 按类型分派的值，深拷贝 deepCloneValue 与结构化哈希 Hash 共用同一套遍历
+type valueVisitor[R any] interface {
+	invalid() R
+	basic(src reflect.Value) R // 布尔、数字与字符串
+	array(src reflect.Value) R
+	slice(src reflect.Value) R
+	mapValue(src reflect.Value) R
+	ptr(src reflect.Value) R
+	structValue(src reflect.Value) R
+	iface(src reflect.Value) R
+	chanValue(src reflect.Value) R
+	funcValue(src reflect.Value) R
+	unsafePointer(src reflect.Value) R
+}
+
+// walkValue 按 src 的类型调用 v 对应的方法，递归由各个方法自己完成
+func walkValue[R any](src reflect.Value, v valueVisitor[R]) R {
+	if !src.IsValid() {
+		return v.invalid()
+	}
+
+	switch src.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128, reflect.String:
+		return v.basic(src)
+
+	case reflect.Array:
+		return v.array(src)
+
+	case reflect.Slice:
+		return v.slice(src)
+
+	case reflect.Map:
+		return v.mapValue(src)
+
+	case reflect.Ptr:
+		return v.ptr(src)
+
+	case reflect.Struct:
+		return v.structValue(src)
+
+	case reflect.Interface:
+		return v.iface(src)
+
+	case reflect.Chan:
+		return v.chanValue(src)
+
+	case reflect.Func:
+		return v.funcValue(src)
+
+	case reflect.UnsafePointer:
+		return v.unsafePointer(src)
+
+	default:
+		return v.invalid()
+	}
+}
@@ -1,5 +1,16 @@
 package y
 
+import (
+	"fmt"
+	"time"
+)
+
+// Option 是所有选项的统一接口，UseAsync 等常量与 WithLimit 等带参数的选项都实现了它
+// 函数会忽略不支持的选项，使用 -tags debug 构建时则会 panic，便于尽早发现传错的选项
+type Option interface {
+	optionKind() option
+}
+
 type option int
 
 const (
@@ -15,9 +26,164 @@ const (
 	RMap
 	// isFlatFlex
 	isFlatFlex
+
+	// 收集所有错误而不是在第一个错误处停止
+	UseAllErrors
+	// 从后向前查找
+	UseReverse
+	// 去重时保留第一个/最后一个重复元素
+	KeepFirst
+	KeepLast
+
+	// 带参数选项的类别，仅用于检查选项是否被支持
+	withLimit
+	withOffset
+	withTimeout
+	withKey
+	withHasher
+	withEqual
+	withMerge
+	withPriority
+	withPool
 )
 
+var optionNames = [...]string{
+	UseAsync:     "UseAsync",
+	UseDistinct:  "UseDistinct",
+	UsePanic:     "UsePanic",
+	NotNil:       "NotNil",
+	NotEmpty:     "NotEmpty",
+	Not:          "Not",
+	Is:           "Is",
+	RMap:         "RMap",
+	isFlatFlex:   "isFlatFlex",
+	UseAllErrors: "UseAllErrors",
+	UseReverse:   "UseReverse",
+	KeepFirst:    "KeepFirst",
+	KeepLast:     "KeepLast",
+	withLimit:    "WithLimit",
+	withOffset:   "WithOffset",
+	withTimeout:  "WithTimeout",
+	withKey:      "WithKey",
+	withHasher:   "WithHasher",
+	withEqual:    "WithEqual",
+	withMerge:    "WithMerge",
+	withPriority: "WithPriority",
+	withPool:     "WithPool",
+}
+
+func (o option) String() string {
+	if o >= 0 && int(o) < len(optionNames) {
+		return optionNames[o]
+	}
+	return fmt.Sprintf("option(%d)", int(o))
+}
+
+func (o option) optionKind() option {
+	return o
+}
+
 type OptionContext struct {
 	async    bool
 	distinct bool
 }
+
+// limitOption 并发数限制，通过 WithLimit 创建
+type limitOption int
+
+// WithLimit 指定异步执行时的最大并发数，n <= 0 时使用 runtime.GOMAXPROCS(0)
+func WithLimit(n int) limitOption {
+	return limitOption(n)
+}
+
+func (limitOption) optionKind() option {
+	return withLimit
+}
+
+// offsetOption 查找的起始下标，通过 WithOffset 创建
+type offsetOption int
+
+// WithOffset 指定查找的起始下标，负数表示从末尾倒数
+func WithOffset(n int) offsetOption {
+	return offsetOption(n)
+}
+
+func (offsetOption) optionKind() option {
+	return withOffset
+}
+
+// timeoutOption 超时时间，通过 WithTimeout 创建
+type timeoutOption time.Duration
+
+// WithTimeout 为接收 ctx 或返回 error 的函数指定整体超时时间，超时后不再启动新的任务并返回 context.DeadlineExceeded
+func WithTimeout(d time.Duration) timeoutOption {
+	return timeoutOption(d)
+}
+
+func (timeoutOption) optionKind() option {
+	return withTimeout
+}
+
+// keyOption 取键函数，通过 WithKey 创建
+type keyOption struct {
+	fn any
+}
+
+// WithKey 指定去重、集合运算时的取键函数，写法与 Distinct 的 fn 一致
+//
+//	y.Union(a, b, y.WithKey(func(u User) any { return u.ID }))
+func WithKey(fn any) keyOption {
+	return keyOption{fn: fn}
+}
+
+func (keyOption) optionKind() option {
+	return withKey
+}
+
+// unwrapKey 取出 WithKey 中的取键函数，其他值原样返回
+func unwrapKey(fn any) any {
+	if key, ok := fn.(keyOption); ok {
+		return key.fn
+	}
+	return fn
+}
+
+// 各函数支持的选项
+var (
+	flexOptions       = []option{UseAsync, UseDistinct, UsePanic, NotNil, NotEmpty, isFlatFlex, withLimit, withPool}
+	flexCtxOptions    = []option{UseAsync, UseDistinct, NotNil, NotEmpty, UseAllErrors, withLimit, withTimeout}
+	filterOptions     = []option{Is, Not, NotNil, NotEmpty}
+	findOptions       = []option{NotNil, NotEmpty, UseReverse, withOffset}
+	seqOptions        = []option{UseAsync, UseDistinct, NotNil, NotEmpty}
+	distinctOptions   = []option{withKey}
+	distinctByOptions = []option{KeepFirst, KeepLast, withHasher, withEqual, withMerge}
+	setOptions        = []option{UseAsync, withKey}
+	eachOptions       = []option{withLimit, withTimeout}
+	pickOptions       = []option{UseDistinct, withPool}
+	submitOptions     = []option{withPriority}
+)
+
+// checkOptions 在 debug 构建中检查 opts 是否都被 name 对应的函数支持，不支持时 panic
+// 非 Option 的值（如 Is/Not 后的值列表、取键函数）不做检查
+func checkOptions[O any](name string, opts []O, supported []option) {
+	if !debug {
+		return
+	}
+	for _, opt := range opts {
+		o, ok := any(opt).(Option)
+		if !ok {
+			continue
+		}
+		kind := o.optionKind()
+		found := false
+		for _, s := range supported {
+			if s == kind {
+				found = true
+				break
+			}
+		}
+		if !found {
+			panic(fmt.Sprintf("y.%s: unsupported option %s", name, kind))
+		}
+	}
+}
@@ -0,0 +1,6 @@
+//go:build debug
+
+package y
+
+// debug 构建（-tags debug）会检查传入的选项是否被支持
+const debug = true
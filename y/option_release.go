@@ -0,0 +1,5 @@
+//go:build !debug
+
+package y
+
+const debug = false
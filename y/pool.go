@@ -0,0 +1,310 @@
+package y
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrPoolClosed 在 WorkerPool 调用 Shutdown 之后提交任务时返回
+	ErrPoolClosed = errors.New("worker pool closed")
+	// ErrPoolFull 在使用 TrySubmit 提交且队列已满时返回
+	ErrPoolFull = errors.New("worker pool queue full")
+)
+
+// Priority 任务优先级，优先级高的任务先出队，同一优先级按提交顺序执行
+type Priority int
+
+const (
+	PriorityLow Priority = iota
+	PriorityNormal
+	PriorityHigh
+
+	priorityCount = iota
+)
+
+type PoolOption struct {
+	MinWorkers  int           // 常驻的工作协程数，与 MaxWorkers 相同时为固定大小
+	MaxWorkers  int           // 最大工作协程数，0表示 runtime.GOMAXPROCS(0)
+	QueueSize   int           // 等待队列长度，队列满时 Submit 阻塞，0表示与 MaxWorkers 相同
+	IdleTimeout time.Duration // 超出 MinWorkers 的协程空闲多久后退出，0表示1分钟
+}
+
+// WorkerPool 是长期存在的协程池，可以在多个调用方之间共享以限制总的并发数
+// 任务按优先级排队，队列有界，满了以后 Submit 会阻塞直到有空位或 ctx 结束
+// 可以通过 WithPool(pool) 让 Flex、FlatFlex、Pick 在池中执行
+type WorkerPool struct {
+	mu          sync.Mutex
+	queues      [priorityCount][]func()
+	slots       chan struct{} // 队列容量的信号量
+	wake        chan struct{}
+	quit        chan struct{}
+	workers     int
+	idle        int // 等待中且没有被 enqueue 认领的协程数
+	minWorkers  int
+	maxWorkers  int
+	idleTimeout time.Duration
+	closed      bool
+	wg          sync.WaitGroup
+}
+
+func NewWorkerPool(opts PoolOption) *WorkerPool {
+	maxWorkers := opts.MaxWorkers
+	if maxWorkers <= 0 {
+		maxWorkers = runtime.GOMAXPROCS(0)
+	}
+	minWorkers := opts.MinWorkers
+	if minWorkers < 0 {
+		minWorkers = 0
+	}
+	if minWorkers > maxWorkers {
+		minWorkers = maxWorkers
+	}
+	queueSize := opts.QueueSize
+	if queueSize <= 0 {
+		queueSize = maxWorkers
+	}
+	idleTimeout := opts.IdleTimeout
+	if idleTimeout <= 0 {
+		idleTimeout = time.Minute
+	}
+	p := &WorkerPool{
+		slots:       make(chan struct{}, queueSize),
+		wake:        make(chan struct{}, maxWorkers), // 每个认领对应一个等待中的协程，不会超过 maxWorkers
+		quit:        make(chan struct{}),
+		minWorkers:  minWorkers,
+		maxWorkers:  maxWorkers,
+		idleTimeout: idleTimeout,
+	}
+	p.mu.Lock()
+	for i := 0; i < minWorkers; i++ {
+		p.spawn()
+	}
+	p.mu.Unlock()
+	return p
+}
+
+// priorityOption 任务优先级，通过 WithPriority 创建
+type priorityOption Priority
+
+// WithPriority 指定提交到 WorkerPool 的任务优先级，默认为 PriorityNormal
+func WithPriority(p Priority) priorityOption {
+	return priorityOption(p)
+}
+
+func (priorityOption) optionKind() option {
+	return withPriority
+}
+
+// poolOption 共享的协程池，通过 WithPool 创建
+type poolOption struct {
+	pool *WorkerPool
+}
+
+// WithPool 让 Flex、FlatFlex、Pick 在指定的 WorkerPool 中并发执行，而不是每次调用都创建新的协程
+// 不要在池中的任务里再以同一个池调用这些函数，队列满时会互相等待
+func WithPool(p *WorkerPool) poolOption {
+	return poolOption{pool: p}
+}
+
+func (poolOption) optionKind() option {
+	return withPool
+}
+
+// Submit 将 fn 提交到协程池并返回 Future，支持 WithPriority
+// 队列满时阻塞，等待期间 ctx 结束或池已关闭时 Future 直接以对应的错误完成；fn 的 panic 会转换为错误
+func Submit[T any](ctx context.Context, p *WorkerPool, fn func(context.Context) (T, error), opts ...Option) *Future[T] {
+	checkOptions("Submit", opts, submitOptions)
+	return submit(ctx, p, fn, opts, true)
+}
+
+// TrySubmit 与 Submit 相同，但队列满时不等待，直接以 ErrPoolFull 完成
+func TrySubmit[T any](ctx context.Context, p *WorkerPool, fn func(context.Context) (T, error), opts ...Option) *Future[T] {
+	checkOptions("TrySubmit", opts, submitOptions)
+	return submit(ctx, p, fn, opts, false)
+}
+
+func submit[T any](ctx context.Context, p *WorkerPool, fn func(context.Context) (T, error), opts []Option, wait bool) *Future[T] {
+	priority := PriorityNormal
+	for _, opt := range opts {
+		if opt, ok := opt.(priorityOption); ok {
+			priority = Priority(opt)
+		}
+	}
+	f := newFuture[T]()
+	task := func() {
+		var v T
+		var err error
+		if perr := Try(func() { v, err = fn(ctx) }); perr != nil {
+			err = perr
+		}
+		f.complete(v, err)
+	}
+	if err := p.enqueue(ctx, priority, task, wait); err != nil {
+		f.complete(*new(T), err)
+	}
+	return f
+}
+
+func (p *WorkerPool) enqueue(ctx context.Context, priority Priority, task func(), wait bool) error {
+	if priority < PriorityLow {
+		priority = PriorityLow
+	}
+	if priority > PriorityHigh {
+		priority = PriorityHigh
+	}
+	if wait {
+		select {
+		case p.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-p.quit:
+			return ErrPoolClosed
+		}
+	} else {
+		select {
+		case p.slots <- struct{}{}:
+		default:
+			return ErrPoolFull
+		}
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		<-p.slots
+		return ErrPoolClosed
+	}
+	p.queues[priority] = append(p.queues[priority], task)
+	if p.idle > 0 {
+		// 在锁内认领一个空闲协程，连续提交时后面的任务不会再指望同一个协程
+		p.idle--
+		p.wake <- struct{}{}
+	} else if p.workers < p.maxWorkers {
+		p.spawn()
+	}
+	p.mu.Unlock()
+	return nil
+}
+
+// spawn 启动一个工作协程，需要持有锁
+func (p *WorkerPool) spawn() {
+	p.workers++
+	p.wg.Add(1)
+	go p.work()
+}
+
+// pop 取出优先级最高的任务，需要持有锁
+func (p *WorkerPool) pop() func() {
+	for i := len(p.queues) - 1; i >= 0; i-- {
+		if q := p.queues[i]; len(q) > 0 {
+			task := q[0]
+			q[0] = nil
+			p.queues[i] = q[1:]
+			return task
+		}
+	}
+	return nil
+}
+
+func (p *WorkerPool) work() {
+	defer p.wg.Done()
+	timer := time.NewTimer(p.idleTimeout)
+	defer timer.Stop()
+	for {
+		p.mu.Lock()
+		task := p.pop()
+		if task == nil {
+			if p.closed {
+				p.workers--
+				p.mu.Unlock()
+				return
+			}
+			p.idle++
+			p.mu.Unlock()
+
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(p.idleTimeout)
+			timeout := false
+			select {
+			case <-p.wake:
+				// enqueue 认领时已经减少了 idle
+				continue
+			case <-p.quit:
+			case <-timer.C:
+				timeout = true
+			}
+			p.mu.Lock()
+			select {
+			case <-p.wake:
+				// 超时或关闭的同时被认领，替被认领的协程处理任务
+				p.mu.Unlock()
+				continue
+			default:
+			}
+			p.idle--
+			if timeout && p.workers > p.minWorkers && !p.hasTask() {
+				p.workers--
+				p.mu.Unlock()
+				return
+			}
+			p.mu.Unlock()
+			continue
+		}
+		p.mu.Unlock()
+		<-p.slots
+		task()
+	}
+}
+
+// hasTask 队列中是否还有任务，需要持有锁
+func (p *WorkerPool) hasTask() bool {
+	for _, q := range p.queues {
+		if len(q) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Workers 返回当前的工作协程数
+func (p *WorkerPool) Workers() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.workers
+}
+
+// Shutdown 停止接收新任务，并等待已提交的任务全部执行完
+// ctx 先结束时返回 ctx 的错误，剩余任务仍会在后台继续执行
+func (p *WorkerPool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.quit)
+		// 队列中还有任务但没有工作协程时补一个，保证队列被清空
+		if p.workers == 0 && p.hasTask() {
+			p.spawn()
+		}
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,95 @@
+package y
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock 抽象时间的获取与等待，默认使用 SystemClock，测试中可以替换为 FakeClock 以避免真实的等待
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SystemClock 基于 time 包的真实时钟
+var SystemClock Clock = systemClock{}
+
+// FakeClock 是手动推进的时钟，只有调用 Advance 时时间才会前进
+type FakeClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	until time.Time
+	ch    chan time.Time
+}
+
+// NewFakeClock 创建一个从 start 开始的 FakeClock
+func NewFakeClock(start time.Time) *FakeClock {
+	c := &FakeClock{now: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After 返回在时钟推进 d 之后触发的 channel，d <= 0 时立即触发
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeWaiter{until: c.now.Add(d), ch: ch})
+	c.cond.Broadcast()
+	return ch
+}
+
+// Advance 将时钟推进 d，并按时间顺序触发所有到期的 After
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	sort.SliceStable(c.waiters, func(i, j int) bool {
+		return c.waiters[i].until.Before(c.waiters[j].until)
+	})
+	n := 0
+	for _, w := range c.waiters {
+		if w.until.After(c.now) {
+			c.waiters[n] = w
+			n++
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = c.waiters[:n]
+}
+
+// BlockUntil 阻塞直到至少有 n 个未触发的 After，用于在推进时钟前等待被测协程进入等待状态
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}